@@ -0,0 +1,29 @@
+package cache
+
+import "time"
+
+// Entry = cached payload with the time it was stored
+type Entry struct {
+	Value    []byte
+	StoredAt time.Time
+}
+
+// Age returns how long ago the entry was stored
+func (e Entry) Age() time.Duration {
+	return time.Since(e.StoredAt)
+}
+
+// Fresh reports whether the entry is younger than ttl
+func (e Entry) Fresh(ttl time.Duration) bool {
+	return e.Age() < ttl
+}
+
+// Cache stores payloads by key, implementations are safe for concurrent use.
+//
+// Entries older than the cache TTL are dropped. The TTL is how long an entry
+// is kept at all, callers decide with Entry.Fresh when it should be refreshed.
+type Cache interface {
+	Get(key string) (Entry, bool)
+	Set(key string, value []byte) error
+	Delete(key string) error
+}
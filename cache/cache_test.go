@@ -0,0 +1,261 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testBackend runs the behaviour every Cache implementation shares
+func testBackend(t *testing.T, c Cache) {
+	if _, ok := c.Get("missing"); ok {
+		t.Error("Get of a missing key succeeded")
+	}
+	if err := c.Set("profile/octocat", []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := c.Get("profile/octocat")
+	if !ok || string(entry.Value) != "v1" {
+		t.Fatalf("Get = %q, %v, want v1", entry.Value, ok)
+	}
+	if entry.StoredAt.IsZero() || entry.Age() > time.Minute {
+		t.Errorf("StoredAt = %v, want now", entry.StoredAt)
+	}
+	if err := c.Set("profile/octocat", []byte("v2")); err != nil {
+		t.Fatal(err)
+	}
+	if entry, _ := c.Get("profile/octocat"); string(entry.Value) != "v2" {
+		t.Errorf("Get after overwrite = %q, want v2", entry.Value)
+	}
+	if err := c.Delete("profile/octocat"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("profile/octocat"); ok {
+		t.Error("Get after Delete succeeded")
+	}
+	if err := c.Delete("profile/octocat"); err != nil {
+		t.Errorf("Delete of a missing key = %v", err)
+	}
+}
+
+func TestMemory(t *testing.T) {
+	testBackend(t, NewMemory(time.Hour, 10))
+}
+
+func TestMemoryEvictsLeastRecentlyUsed(t *testing.T) {
+	m := NewMemory(0, 2)
+	m.Set("a", []byte("a"))
+	m.Set("b", []byte("b"))
+	// a becomes the most recently used
+	m.Get("a")
+	m.Set("c", []byte("c"))
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := m.Get(key); ok != want {
+			t.Errorf("Get(%q) ok = %v, want %v", key, ok, want)
+		}
+	}
+}
+
+func TestMemoryTTL(t *testing.T) {
+	m := NewMemory(20*time.Millisecond, 0)
+	m.Set("a", []byte("a"))
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := m.Get("a"); ok {
+		t.Error("expired entry was returned")
+	}
+
+	forever := NewMemory(0, 0)
+	forever.Set("a", []byte("a"))
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := forever.Get("a"); !ok {
+		t.Error("entry of a cache without ttl expired")
+	}
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "cache-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestFile(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	f, err := NewFile(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testBackend(t, f)
+}
+
+func TestFileSurvivesRestart(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	f, _ := NewFile(dir, time.Hour)
+	if err := f.Set("topstar?score_by=stars", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, _ := NewFile(dir, time.Hour)
+	entry, ok := reopened.Get("topstar?score_by=stars")
+	if !ok || string(entry.Value) != "data" {
+		t.Errorf("Get after reopening = %q, %v, want data", entry.Value, ok)
+	}
+}
+
+func TestFileTTL(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	f, _ := NewFile(dir, time.Hour)
+	f.Set("a", []byte("a"))
+	old := time.Now().Add(-2 * time.Hour)
+	os.Chtimes(f.path("a"), old, old)
+	if _, ok := f.Get("a"); ok {
+		t.Error("expired entry was returned")
+	}
+
+	forever, _ := NewFile(dir, 0)
+	if _, ok := forever.Get("a"); !ok {
+		t.Error("entry of a cache without ttl expired")
+	}
+}
+
+func TestFileKeysStayInDir(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	f, _ := NewFile(dir, 0)
+	if err := f.Set("../../escape", []byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("%d files in dir, want 1", len(files))
+	}
+}
+
+func TestSplitKeepsPinnedEntries(t *testing.T) {
+	s := &Split{
+		Pinned:  NewMemory(0, 0),
+		Default: NewMemory(0, 2),
+		Pin:     func(key string) bool { return key == "topstar" },
+	}
+	testBackend(t, s)
+
+	s.Set("topstar", []byte("crawl"))
+	for i := 0; i < 10; i++ {
+		s.Set("profile/"+strconv.Itoa(i), []byte("p"))
+	}
+	if _, ok := s.Get("topstar"); !ok {
+		t.Error("pinned entry was evicted by other entries")
+	}
+	if _, ok := s.Get("profile/0"); ok {
+		t.Error("unpinned entry wasn't evicted")
+	}
+}
+
+func TestGroupDeduplicates(t *testing.T) {
+	var g Group
+	var loads int32
+	release := make(chan struct{})
+	fn := func(ctx context.Context) ([]byte, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return []byte("value"), nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 5)
+	shared := make([]bool, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			b, s, err := g.Do(context.Background(), "key", fn)
+			if err != nil {
+				t.Error(err)
+			}
+			results[i], shared[i] = string(b), s
+		}(i)
+	}
+	// Let every caller join before the load finishes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&loads); n != 1 {
+		t.Errorf("%d loads, want 1", n)
+	}
+	sharedCount := 0
+	for i, r := range results {
+		if r != "value" {
+			t.Errorf("caller %d got %q", i, r)
+		}
+		if shared[i] {
+			sharedCount++
+		}
+	}
+	if sharedCount != 4 {
+		t.Errorf("%d callers shared the load, want 4", sharedCount)
+	}
+}
+
+func TestGroupCancelsOnlyWhenEveryCallerLeft(t *testing.T) {
+	var g Group
+	cancelled := make(chan struct{})
+	fn := func(ctx context.Context) ([]byte, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	}
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() { _, _, err := g.Do(ctx1, "key", fn); errs <- err }()
+	go func() { _, _, err := g.Do(ctx2, "key", fn); errs <- err }()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel1()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("first caller err = %v, want context.Canceled", err)
+	}
+	select {
+	case <-cancelled:
+		t.Fatal("load was cancelled while a caller was still waiting")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancel2()
+	<-errs
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("load wasn't cancelled once every caller left")
+	}
+}
+
+func TestGroupRunsAgainAfterDone(t *testing.T) {
+	var g Group
+	var loads int32
+	fn := func(ctx context.Context) ([]byte, error) {
+		atomic.AddInt32(&loads, 1)
+		return nil, errors.New("boom")
+	}
+	for i := 0; i < 3; i++ {
+		if _, _, err := g.Do(context.Background(), "key", fn); err == nil {
+			t.Error("error wasn't returned")
+		}
+	}
+	if n := atomic.LoadInt32(&loads); n != 3 {
+		t.Errorf("%d loads, want 3, results must not be cached", n)
+	}
+}
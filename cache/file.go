@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// File = Cache persisted as one file per key, so cached data survives restarts.
+// The modification time of a file is the time its entry was stored.
+type File struct {
+	mu  sync.RWMutex
+	dir string
+	ttl time.Duration
+}
+
+// NewFile creates file cache in dir, creating the directory when needed.
+// ttl <= 0 keeps entries forever.
+func NewFile(dir string, ttl time.Duration) (*File, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &File{dir: dir, ttl: ttl}, nil
+}
+
+func (f *File) path(key string) string {
+	// PathEscape keeps keys like "profile/octocat" inside dir
+	return filepath.Join(f.dir, url.PathEscape(key)+".cache")
+}
+
+// Get implements Cache
+func (f *File) Get(key string) (Entry, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	path := f.path(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		return Entry{}, false
+	}
+	entry := Entry{StoredAt: info.ModTime()}
+	if f.ttl > 0 && !entry.Fresh(f.ttl) {
+		return Entry{}, false
+	}
+	entry.Value, err = ioutil.ReadFile(path)
+	if err != nil {
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+// Set implements Cache, the file is replaced atomically so readers never see partial data
+func (f *File) Set(key string, value []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tmp, err := ioutil.TempFile(f.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(key))
+}
+
+// Delete implements Cache
+func (f *File) Delete(key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := os.Remove(f.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
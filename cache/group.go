@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"sync"
+)
+
+// Group deduplicates concurrent loads of the same key, like x/sync/singleflight,
+// but the load is cancelled only once every caller waiting for it has given up
+type Group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+type call struct {
+	done    chan struct{}
+	val     []byte
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// Do runs fn once for all concurrent callers of key and returns its result to each of them.
+// shared reports whether the caller joined a load started by someone else.
+func (g *Group) Do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) (val []byte, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+	c, shared := g.calls[key]
+	if !shared {
+		loadCtx, cancel := context.WithCancel(context.Background())
+		c = &call{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+		go g.load(loadCtx, key, c, fn)
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, shared, c.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			c.cancel()
+			g.forget(key, c)
+		}
+		g.mu.Unlock()
+		return nil, shared, ctx.Err()
+	}
+}
+
+func (g *Group) load(ctx context.Context, key string, c *call, fn func(ctx context.Context) ([]byte, error)) {
+	c.val, c.err = fn(ctx)
+	c.cancel()
+
+	g.mu.Lock()
+	g.forget(key, c)
+	g.mu.Unlock()
+	close(c.done)
+}
+
+// forget removes the call unless a newer one already took its place, g.mu must be held
+func (g *Group) forget(key string, c *call) {
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Memory = in-memory Cache bounded by entry count, least recently used entries are evicted first
+type Memory struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List
+}
+
+type memoryItem struct {
+	key   string
+	entry Entry
+}
+
+// NewMemory creates in-memory cache, ttl <= 0 keeps entries forever
+// and maxEntries <= 0 doesn't bound the number of entries
+func NewMemory(ttl time.Duration, maxEntries int) *Memory {
+	return &Memory{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Get implements Cache
+func (m *Memory) Get(key string) (Entry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.entries[key]
+	if !ok {
+		return Entry{}, false
+	}
+	item := el.Value.(*memoryItem)
+	if m.ttl > 0 && !item.entry.Fresh(m.ttl) {
+		m.remove(el)
+		return Entry{}, false
+	}
+	m.lru.MoveToFront(el)
+	return item.entry, true
+}
+
+// Set implements Cache
+func (m *Memory) Set(key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := Entry{Value: value, StoredAt: time.Now()}
+	if el, ok := m.entries[key]; ok {
+		el.Value.(*memoryItem).entry = entry
+		m.lru.MoveToFront(el)
+		return nil
+	}
+	m.entries[key] = m.lru.PushFront(&memoryItem{key: key, entry: entry})
+	for m.maxEntries > 0 && m.lru.Len() > m.maxEntries {
+		m.remove(m.lru.Back())
+	}
+	return nil
+}
+
+// Delete implements Cache
+func (m *Memory) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.remove(el)
+	}
+	return nil
+}
+
+func (m *Memory) remove(el *list.Element) {
+	m.lru.Remove(el)
+	delete(m.entries, el.Value.(*memoryItem).key)
+}
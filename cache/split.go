@@ -0,0 +1,31 @@
+package cache
+
+// Split = Cache routing the keys matched by Pin to a separate cache, e.g. to keep entries
+// which are expensive to rebuild out of an LRU filled by cheap ones
+type Split struct {
+	Pinned  Cache
+	Default Cache
+	Pin     func(key string) bool
+}
+
+func (s *Split) cache(key string) Cache {
+	if s.Pin != nil && s.Pin(key) {
+		return s.Pinned
+	}
+	return s.Default
+}
+
+// Get implements Cache
+func (s *Split) Get(key string) (Entry, bool) {
+	return s.cache(key).Get(key)
+}
+
+// Set implements Cache
+func (s *Split) Set(key string, value []byte) error {
+	return s.cache(key).Set(key, value)
+}
+
+// Delete implements Cache
+func (s *Split) Delete(key string) error {
+	return s.cache(key).Delete(key)
+}
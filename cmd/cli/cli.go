@@ -1,31 +1,348 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"gogithub/config"
 	"gogithub/github"
+	"gogithub/snapshot"
 	"os"
+	"strings"
+	"time"
 )
 
+const usage = `Usage:
+  githubcli [flags] <username>                 profile summary
+  githubcli repos [flags] <username>           repository listing
+  githubcli contributions [flags] <username>   contribution statistics
+  githubcli history [flags] [username]         recorded profile or top stars trend
+`
+
 func main() {
 	if len(os.Args) == 1 {
 		fmt.Println("Missing argument for username. Example: ./githubcli antonybudianto")
+		fmt.Print(usage)
+		os.Exit(1)
+	}
+
+	// History is read from SNAPSHOT_DIR, it doesn't need a token
+	if os.Args[1] == "history" {
+		showHistory(os.Args[2:])
+		return
+	}
+
+	client := github.NewClient(config.GithubAccessToken())
+	client.URL = config.GithubGraphQLURL()
+	client.Parallelism = config.Parallelism()
+
+	switch os.Args[1] {
+	case "repos":
+		listRepos(client, os.Args[2:])
+		return
+	case "contributions":
+		showContributions(client, os.Args[2:])
+		return
+	}
+
+	var opts github.FetchOptions
+	flags := flag.NewFlagSet("githubcli", flag.ExitOnError)
+	flags.IntVar(&opts.TopN, "top", 5, "number of top repositories")
+	flags.StringVar(&opts.TopBy, "top-by", "stars", "rank top repositories by stars, forks or pushed")
+	flags.BoolVar(&opts.Languages, "languages", false, "show the byte-weighted language breakdown")
+	record := flags.Bool("record", false, "record the counts in SNAPSHOT_DIR for the history command")
+	repoFilterFlags(flags, &opts)
+	flags.Parse(os.Args[1:])
+	if flags.NArg() != 1 {
+		fmt.Println("Missing argument for username. Example: ./githubcli -top=10 antonybudianto")
+		os.Exit(1)
+	}
+	if err := opts.Validate(); err != nil {
+		fmt.Println(err)
 		os.Exit(1)
 	}
-	username := os.Args[1]
+	username := flags.Arg(0)
 
-	data, err := github.FetchAllRepos(username)
+	data, err := client.FetchAllRepos(context.Background(), username, opts)
 
 	if err != nil {
-		fmt.Println("Error fetch all repo", err.Error())
+		fmt.Println(describeError(err))
 		os.Exit(1)
 	}
 
-	fmt.Printf("%s\n", username)
+	if *record {
+		recordProfile(username, opts, data)
+	}
+
+	fmt.Printf("%s (%s)\n", username, data.OwnerType)
+	if data.OwnerType == github.OwnerTypeOrganization {
+		fmt.Printf("%d members\n", data.MemberCount)
+	}
 	fmt.Printf("%d stars\n", data.StarCount)
 	fmt.Printf("%d repos\n", data.RepoCount)
 	fmt.Printf("%d forks\n", data.ForkCount)
 	b, _ := json.MarshalIndent(data.LanguageMap, "", "  ")
 	str := string(b)
 	fmt.Printf("LangMap: %v\n", str)
+	if opts.Languages {
+		fmt.Println("Languages by size:")
+		for _, lang := range data.Languages {
+			fmt.Printf("    %-20s %6.2f%% %12d bytes\n", lang.Name, lang.Percent, lang.Bytes)
+		}
+	}
+	fmt.Printf("Top repos by %s:\n", opts.TopBy)
+	for i, repo := range data.TopRepos {
+		fmt.Printf("%2d. %-40s %6d stars %5d forks  pushed %s\n",
+			i+1, repo.Name, repo.Stars, repo.Forks, repo.PushedAt.Format("2006-01-02"))
+	}
+}
+
+// listRepos prints a page of the user's repositories, e.g. repos -sort=pushed -language=Go antonybudianto
+func listRepos(client *github.Client, args []string) {
+	var opts github.RepoListOptions
+	var fetchOpts github.FetchOptions
+	flags := flag.NewFlagSet("repos", flag.ExitOnError)
+	flags.StringVar(&opts.Sort, "sort", "", "sort by name, stars, forks, language, license, created or pushed (default stars)")
+	flags.StringVar(&opts.Order, "order", "", "asc or desc")
+	flags.StringVar(&opts.Language, "language", "", "only repositories in this language")
+	flags.IntVar(&opts.MinStars, "min-stars", 0, "only repositories with at least this many stars")
+	flags.IntVar(&opts.Page, "page", 1, "page number")
+	flags.IntVar(&opts.PerPage, "per-page", 30, "repositories per page, at most 100")
+	repoFilterFlags(flags, &fetchOpts)
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		fmt.Println("Missing argument for username. Example: ./githubcli repos -sort=pushed antonybudianto")
+		os.Exit(1)
+	}
+	if err := opts.Validate(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := fetchOpts.Validate(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	username := flags.Arg(0)
+
+	repos, err := client.FetchRepositories(context.Background(), username, fetchOpts)
+	if err != nil {
+		fmt.Println(describeError(err))
+		os.Exit(1)
+	}
+
+	page := github.ListRepositories(repos, opts)
+	fmt.Printf("%s: %d repos, page %d/%d\n", username, page.Total, page.Page, page.Pages)
+	for _, repo := range page.Repositories {
+		fmt.Printf("%-40s %6d stars %5d forks  %-12s pushed %s\n",
+			repo.Name, repo.Stars, repo.Forks, repo.Language, repo.PushedAt.Format("2006-01-02"))
+		if len(repo.Topics) > 0 {
+			fmt.Printf("    topics: %s\n", strings.Join(repo.Topics, ", "))
+		}
+	}
+}
+
+// showContributions prints the contribution statistics, e.g. contributions -from=2024-01-01 antonybudianto
+func showContributions(client *github.Client, args []string) {
+	flags := flag.NewFlagSet("contributions", flag.ExitOnError)
+	from := flags.String("from", "", "start date, YYYY-MM-DD (default one year ago)")
+	to := flags.String("to", "", "end date included, YYYY-MM-DD (default today)")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		fmt.Println("Missing argument for username. Example: ./githubcli contributions -from=2024-01-01 antonybudianto")
+		os.Exit(1)
+	}
+	opts, err := github.ParseContributionRange(*from, *to)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	username := flags.Arg(0)
+
+	data, err := client.FetchContributions(context.Background(), username, opts)
+	if err != nil {
+		fmt.Println(describeError(err))
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s: %d contributions from %s to %s\n", username, data.TotalContributions,
+		data.From.Format("2006-01-02"), data.To.Format("2006-01-02"))
+	fmt.Printf("%d commits\n", data.Commits)
+	fmt.Printf("%d pull requests\n", data.PullRequests)
+	fmt.Printf("%d issues\n", data.Issues)
+	fmt.Printf("%d reviews\n", data.Reviews)
+	fmt.Printf("%d repositories created\n", data.RepositoriesCreated)
+	fmt.Printf("%d private contributions\n", data.Restricted)
+	fmt.Printf("Longest streak: %d days (%s - %s)\n", data.LongestStreak.Days, data.LongestStreak.Start, data.LongestStreak.End)
+	fmt.Printf("Current streak: %d days\n", data.CurrentStreak.Days)
+	fmt.Println("Contributed to:")
+	for _, rc := range data.ContributedTo {
+		fmt.Printf("    %-50s %5d commits %4d pull requests\n", rc.Repository, rc.Commits, rc.PullRequests)
+	}
+}
+
+// recordProfile keeps the counts of the default repository selection for the history command
+func recordProfile(username string, opts github.FetchOptions, data *github.RepoData) {
+	dir := config.SnapshotDir()
+	if dir == "" {
+		fmt.Println("History is disabled, set SNAPSHOT_DIR")
+		return
+	}
+	if !opts.DefaultSelection() {
+		fmt.Println("Only the default repository selection is recorded")
+		return
+	}
+	snapshots, err := snapshot.Open(dir)
+	if err == nil {
+		snapshots.Retention = config.SnapshotRetention()
+		now := time.Now()
+		if err = snapshots.RecordProfile(username, snapshot.NewProfilePoint(now, data)); err == nil {
+			err = snapshots.Prune(now)
+		}
+	}
+	if err != nil {
+		fmt.Println("Failed to record profile history:", err)
+	}
+}
+
+// showHistory prints the recorded trend of a profile, e.g. history -window=12w antonybudianto,
+// or the rank changes of the leaderboard with -topstars
+func showHistory(args []string) {
+	flags := flag.NewFlagSet("history", flag.ExitOnError)
+	window := flags.String("window", "30d", "how far back to look, e.g. 30d, 12w or 72h")
+	topStars := flags.Bool("topstars", false, "show the top stars rank changes instead of a profile")
+	score := github.ScoreOptions{
+		MinStars:     config.TopStarsMinStars(),
+		MinForks:     config.TopStarsMinForks(),
+		MinFollowers: config.TopStarsMinFollowers(),
+		MinRepos:     config.TopStarsMinRepos(),
+	}
+	flags.StringVar(&score.By, "score-by", config.TopStarsScoreBy(),
+		"rank top stars by stars, forks, followers, composite or stars_per_repo")
+	flags.IntVar(&score.MinStars, "min-stars", score.MinStars, "minimum stars of a ranked developer")
+	flags.IntVar(&score.MinForks, "min-forks", score.MinForks, "minimum forks of a ranked developer")
+	flags.IntVar(&score.MinFollowers, "min-followers", score.MinFollowers, "minimum followers of a ranked developer")
+	flags.IntVar(&score.MinRepos, "min-repos", score.MinRepos, "minimum repositories of a ranked developer")
+	weights := flags.String("weights", config.TopStarsWeights(), "weights of the composite score")
+	flags.Parse(args)
+
+	if !*topStars && flags.NArg() != 1 {
+		fmt.Println("Missing argument for username. Example: ./githubcli history -window=12w antonybudianto")
+		os.Exit(1)
+	}
+	d, err := snapshot.ParseWindow(*window)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	dir := config.SnapshotDir()
+	if dir == "" {
+		fmt.Println("History is disabled, set SNAPSHOT_DIR")
+		os.Exit(1)
+	}
+	snapshots, err := snapshot.Open(dir)
+	if err != nil {
+		fmt.Println("Failed to open snapshot store:", err)
+		os.Exit(1)
+	}
+	since := time.Now().Add(-d)
+
+	if *topStars {
+		board, err := github.LoadLeaderboard(config.LeaderboardFile())
+		if err != nil {
+			fmt.Println("Failed to load leaderboard:", err)
+			os.Exit(1)
+		}
+		if score.Weights, err = github.ParseWeights(*weights); err == nil {
+			err = score.Validate()
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		snaps, err := snapshots.TopStars(board.Name, since)
+		if err != nil {
+			fmt.Println("Failed to read history:", err)
+			os.Exit(1)
+		}
+		if len(snaps) == 0 {
+			fmt.Printf("No top stars crawl of %s recorded in the last %s\n", board.Name, *window)
+			return
+		}
+		trend := snapshot.CompareTopStars(snapshot.Rerank(snaps[0], score), snapshot.Rerank(snaps[len(snaps)-1], score))
+		fmt.Printf("%s top stars by %s from %s to %s\n", board.Name, score.By,
+			trend.From.Format("2006-01-02 15:04"), trend.To.Format("2006-01-02 15:04"))
+		for _, dev := range trend.Devs {
+			move := "new"
+			if dev.PreviousRank > 0 {
+				move = fmt.Sprintf("%+d", dev.PreviousRank-dev.Rank)
+			}
+			fmt.Printf("%3d. %-30s %7d stars %+6d  %s\n", dev.Rank, dev.Login, dev.Stars, dev.StarsDelta, move)
+		}
+		for _, dev := range trend.Dropped {
+			fmt.Printf("dropped out: %s (was %d.)\n", dev.Login, dev.Rank)
+		}
+		return
+	}
+
+	username := flags.Arg(0)
+	points, err := snapshots.Profiles(username, since)
+	if err != nil {
+		fmt.Println("Failed to read history:", err)
+		os.Exit(1)
+	}
+	if len(points) == 0 {
+		fmt.Printf("No crawl of %s recorded in the last %s\n", username, *window)
+		return
+	}
+	trend := snapshot.NewProfileTrend(username, points)
+	fmt.Printf("%s from %s to %s\n", username, trend.From.Format("2006-01-02 15:04"), trend.To.Format("2006-01-02 15:04"))
+	fmt.Printf("%+d stars\n", trend.StarsDelta)
+	fmt.Printf("%+d repos\n", trend.ReposDelta)
+	fmt.Printf("%+d forks\n", trend.ForksDelta)
+	for _, p := range trend.Points {
+		fmt.Printf("    %s %7d stars %5d repos %6d forks\n", p.At.Format("2006-01-02 15:04"), p.Stars, p.Repos, p.Forks)
+	}
+}
+
+// repoFilterFlags registers the flags selecting which repositories are fetched
+func repoFilterFlags(flags *flag.FlagSet, opts *github.FetchOptions) {
+	flags.BoolVar(&opts.IncludeForks, "forks", false, "include forks")
+	flags.BoolVar(&opts.ExcludeArchived, "no-archived", false, "exclude archived repositories")
+	flags.BoolVar(&opts.IncludePrivate, "private", false, "include private repositories the token can see")
+	flags.Var((*affiliationsFlag)(&opts.Affiliations), "affiliations",
+		"comma separated owner, collaborator and/or organization_member (default owner)")
+}
+
+// affiliationsFlag = comma separated list of repository affiliations
+type affiliationsFlag []string
+
+func (f *affiliationsFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return strings.Join(*f, ",")
+}
+
+func (f *affiliationsFlag) Set(value string) error {
+	*f = strings.Split(value, ",")
+	return nil
+}
+
+func describeError(err error) string {
+	var notFound *github.NotFoundError
+	var auth *github.AuthError
+	var rateLimit *github.RateLimitError
+	switch {
+	case errors.As(err, &notFound):
+		return fmt.Sprintf("User %s not found", notFound.Login)
+	case errors.As(err, &auth):
+		return "GitHub rejected the access token, please check GH_ACCESS_TOKEN: " + auth.Message
+	case errors.As(err, &rateLimit):
+		return fmt.Sprintf("GitHub rate limit exceeded, try again in %s", rateLimit.Wait().Round(time.Second))
+	}
+	return "Error fetch all repo " + err.Error()
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"net"
+	"time"
 
 	"gogithub/config"
 	"gogithub/github"
@@ -12,6 +13,9 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
+// topStarsTTL = how long FetchTopStars reuses a top stars crawl, like the web server
+const topStarsTTL = 24 * 14 * time.Hour
+
 func main() {
 	addr := config.GrpcServerAddress()
 	lis, err := net.Listen("tcp", addr)
@@ -21,7 +25,24 @@ func main() {
 	log.Println("gRPC server listening at " + addr)
 	s := grpc.NewServer()
 	reflection.Register(s)
-	pb.RegisterGithubServiceServer(s, &github.GrpcServer{})
+	board, err := github.LoadLeaderboard(config.LeaderboardFile())
+	if err != nil {
+		log.Fatalf("failed to load leaderboard: %v", err)
+	}
+	score, err := config.ScoreOptions()
+	if err != nil {
+		log.Fatalf("invalid top stars ranking: %v", err)
+	}
+	client := github.NewClient(config.GithubAccessToken())
+	client.URL = config.GithubGraphQLURL()
+	client.Parallelism = config.Parallelism()
+	pb.RegisterGithubServiceServer(s, &github.GrpcServer{
+		Client:            client,
+		Leaderboard:       board,
+		AllowPrivateRepos: config.AllowPrivateRepos(),
+		DefaultScore:      score,
+		TopStarsTTL:       topStarsTTL,
+	})
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
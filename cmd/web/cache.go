@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gogithub/cache"
+	"gogithub/config"
+	"gogithub/github"
+	"gogithub/model"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	cacheTTLSummary = 24 * time.Hour
+	cacheTTLTopStar = 24 * 14 * time.Hour
+	// stale entries are kept this long after their TTL, so they can still be served
+	// while they're refreshed or when the refresh fails. Summary and top stars never expire.
+	staleRetention = 7 * 24 * time.Hour
+
+	// wait before retrying a failed refresh, doubled on each failure
+	refreshRetryMin = time.Minute
+	refreshRetryMax = time.Hour
+
+	cacheTypeSummary string = "summary"
+	cacheTypeTopStar string = "topstar"
+)
+
+func checkCache(key string, ttl time.Duration) bool {
+	entry, ok := store.Get(key)
+	return ok && entry.Fresh(ttl) && len(entry.Value) != 0
+}
+
+// profileCacheKey = one entry per user and fetch options,
+// e.g. profile/octocat?affiliations=owner&exclude_archived=false&include_forks=false&...&top=5&top_by=stars
+func profileCacheKey(username string, opts github.FetchOptions) string {
+	query := repoFilterValues(opts)
+	query.Set("top", strconv.Itoa(opts.TopN))
+	query.Set("top_by", opts.TopBy)
+	query.Set("languages", strconv.FormatBool(opts.Languages))
+	// GitHub logins are case insensitive
+	return "profile/" + strings.ToLower(username) + "?" + query.Encode()
+}
+
+// topStarsRankedKey holds the configured default ranking of the top stars crawl, other rankings
+// are computed by each request so query parameters can't fill the cache
+const topStarsRankedKey = cacheTypeTopStar + "/default"
+
+func reposCacheKey(username string, opts github.FetchOptions) string {
+	return "repos/" + strings.ToLower(username) + "?" + repoFilterValues(opts).Encode()
+}
+
+// isJobKey reports whether the key holds a background refreshed entry or one of its compressed copies
+func isJobKey(key string) bool {
+	for _, coding := range contentCodings {
+		key = strings.TrimSuffix(key, coding.suffix)
+	}
+	return key == cacheTypeSummary || key == cacheTypeTopStar || key == topStarsRankedKey
+}
+
+// newCache creates the configured backend. Summary and top stars take a whole crawl to rebuild,
+// they're kept apart from the per-user entries so user traffic can never evict them.
+func newCache() cache.Cache {
+	retention := profileCacheTTL + staleRetention
+	switch backend := config.CacheBackend(); backend {
+	case "memory":
+		return &cache.Split{
+			Pinned:  cache.NewMemory(0, 0),
+			Default: cache.NewMemory(retention, config.CacheMaxEntries()),
+			Pin:     isJobKey,
+		}
+	case "file":
+		fileCache, err := cache.NewFile(config.CacheDir(), retention)
+		if err != nil {
+			log.Fatal("Failed to create file cache: ", err)
+		}
+		pinned, err := cache.NewFile(config.CacheDir(), 0)
+		if err != nil {
+			log.Fatal("Failed to create file cache: ", err)
+		}
+		return &cache.Split{Pinned: pinned, Default: fileCache, Pin: isJobKey}
+	default:
+		log.Fatalf("Unknown CACHE_BACKEND %q, use memory or file", backend)
+		return nil
+	}
+}
+
+// processCache crawls GitHub for the job type unless its cache entry is still fresh
+func processCache(ctx context.Context, jobType string) error {
+	if jobType == cacheTypeSummary {
+		if checkCache(cacheTypeSummary, cacheTTLSummary) {
+			return nil
+		}
+		// Rate limit and GraphQL errors are returned as err, so they never get cached
+		data, err := ghClient.FetchTopUserSummary(ctx, leaderboard)
+		if err != nil {
+			return err
+		}
+		b, _ := json.Marshal(&model.ResponsePayload{
+			Data: data,
+		})
+		if err := setCached(cacheTypeSummary, b); err != nil {
+			return err
+		}
+		fmt.Println("summary cache is now updated!")
+	} else if jobType == cacheTypeTopStar {
+		if checkCache(cacheTypeTopStar, cacheTTLTopStar) {
+			return nil
+		}
+		// Every dev is kept, requests rank them by their own score and minimums
+		dataTopStar, devErrors, err := ghClient.FetchAllStars(ctx, leaderboard.TopStars, github.ScoreOptions{})
+		if err != nil {
+			return err
+		}
+		for _, devErr := range devErrors {
+			fmt.Println("ERR", "FetchAllStars:", devErr.Error())
+		}
+		// Prevents caching empty result
+		if len(dataTopStar) == 0 {
+			return fmt.Errorf("FetchAllStars: Empty result")
+		}
+		b, _ := json.Marshal(recordTopStars(dataTopStar))
+		if err := store.Set(cacheTypeTopStar, b); err != nil {
+			return err
+		}
+		fmt.Println("topstar cache is now updated!")
+	}
+	if rl, ok := ghClient.RateLimit(); ok {
+		fmt.Printf("GitHub budget: %d/%d points left, resets at %s\n", rl.Remaining, rl.Limit, rl.ResetAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// refresher keeps a cache entry up to date: it crawls on a schedule,
+// when kicked by a request which found stale data, and retries failed crawls with backoff
+type refresher struct {
+	jobType string
+	ttl     time.Duration
+	trigger chan struct{}
+
+	mu        sync.Mutex
+	retryWait time.Duration
+	nextRetry time.Time
+}
+
+func newRefresher(jobType string, ttl time.Duration) *refresher {
+	return &refresher{
+		jobType: jobType,
+		ttl:     ttl,
+		trigger: make(chan struct{}, 1),
+	}
+}
+
+// kick asks for a refresh without blocking, kicks during a running refresh are coalesced
+func (r *refresher) kick() {
+	select {
+	case r.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// retryAfter estimates when the data will be available
+func (r *refresher) retryAfter() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if wait := time.Until(r.nextRetry); wait > refreshRetryMin {
+		return wait
+	}
+	return refreshRetryMin
+}
+
+func (r *refresher) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Cache refresh outlives the request which triggered it
+		err := processCache(context.Background(), r.jobType)
+		if err != nil {
+			fmt.Println("ERR", r.jobType, "refresh:", err)
+			r.scheduleRetry()
+		} else {
+			r.mu.Lock()
+			r.retryWait = 0
+			r.nextRetry = time.Time{}
+			r.mu.Unlock()
+		}
+
+		select {
+		case <-ticker.C:
+		case <-r.trigger:
+			// Requests keep kicking while data is stale, don't hammer GitHub after a failure
+			r.mu.Lock()
+			wait := time.Until(r.nextRetry)
+			r.mu.Unlock()
+			if wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+	}
+}
+
+func (r *refresher) scheduleRetry() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.retryWait == 0 {
+		r.retryWait = refreshRetryMin
+	} else if r.retryWait *= 2; r.retryWait > refreshRetryMax {
+		r.retryWait = refreshRetryMax
+	}
+	r.nextRetry = time.Now().Add(r.retryWait)
+	time.AfterFunc(r.retryWait, r.kick)
+}
+
+func startRefreshers(interval time.Duration) {
+	refreshers = map[string]*refresher{
+		cacheTypeSummary: newRefresher(cacheTypeSummary, cacheTTLSummary),
+		cacheTypeTopStar: newRefresher(cacheTypeTopStar, cacheTTLTopStar),
+	}
+	for _, r := range refreshers {
+		go r.run(interval)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gogithub/github"
+	"gogithub/model"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// contributionsCacheKey = one entry per user and range, an empty bound is left to GitHub
+func contributionsCacheKey(username string, opts github.ContributionOptions) string {
+	query := url.Values{}
+	query.Set("from", "")
+	query.Set("to", "")
+	if !opts.From.IsZero() {
+		query.Set("from", opts.From.UTC().Format(time.RFC3339))
+	}
+	if !opts.To.IsZero() {
+		query.Set("to", opts.To.UTC().Format(time.RFC3339))
+	}
+	return "contributions/" + strings.ToLower(username) + "?" + query.Encode()
+}
+
+// fetchContributions crawls the contribution statistics and caches the response payload
+func fetchContributions(ctx context.Context, key, username string, opts github.ContributionOptions) ([]byte, error) {
+	data, err := ghClient.FetchContributions(ctx, username, opts)
+	if err != nil {
+		return nil, err
+	}
+	b, _ := json.Marshal(model.ResponsePayload{
+		Data: data,
+	})
+	if err := setCached(key, b); err != nil {
+		fmt.Println("ERR fetchContributions: cache:", err)
+	}
+	return b, nil
+}
+
+// handleGithubContributions serves the contribution statistics, e.g. ?from=2024-01-01&to=2024-12-31
+func handleGithubContributions(w http.ResponseWriter, r *http.Request) {
+	username := pathParam(r, "login")
+	query := r.URL.Query()
+
+	opts, err := github.ParseContributionRange(query.Get("from"), query.Get("to"))
+	if err != nil {
+		writeParamError(w, err)
+		return
+	}
+
+	key := contributionsCacheKey(username, opts)
+	entry, cacheStatus, err := cachedUserData(r.Context(), key, func(ctx context.Context) ([]byte, error) {
+		return fetchContributions(ctx, key, username, opts)
+	})
+	if err != nil {
+		fmt.Println("ERR handleGithubContributions:", err.Error())
+		writeError(w, errorFromGithub(err))
+		return
+	}
+
+	writeEntry(w, r, key, entry, cacheStatus)
+}
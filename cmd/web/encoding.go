@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"gogithub/cache"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+// contentCoding = compression a pre-compressed copy of an entry is stored with
+type contentCoding struct {
+	// name as in Accept-Encoding and Content-Encoding, also the suffix of the ETag
+	name string
+	// suffix = cache key suffix of the pre-compressed copy
+	suffix    string
+	newWriter func(w io.Writer) io.WriteCloser
+}
+
+var (
+	brotliCoding = contentCoding{"br", ".br", func(w io.Writer) io.WriteCloser {
+		return brotli.NewWriterLevel(w, brotli.BestCompression)
+	}}
+	gzipCoding = contentCoding{"gzip", ".gz", func(w io.Writer) io.WriteCloser {
+		zw, _ := gzip.NewWriterLevel(w, gzip.BestCompression)
+		return zw
+	}}
+	// contentCodings in order of preference
+	contentCodings = []contentCoding{brotliCoding, gzipCoding}
+)
+
+// compress returns b encoded with the coding
+func (c contentCoding) compress(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := c.newWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// setCached stores the payload together with its pre-compressed copies
+func setCached(key string, b []byte) error {
+	if err := store.Set(key, b); err != nil {
+		return err
+	}
+	for _, coding := range contentCodings {
+		z, err := coding.compress(b)
+		if err != nil {
+			return err
+		}
+		if err := store.Set(key+coding.suffix, z); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compressed returns the pre-compressed copy of the entry, compressing it again
+// when the copy is missing or older than the entry. Entries without a key aren't cached.
+func compressed(key string, entry cache.Entry, coding contentCoding) ([]byte, error) {
+	if key == "" {
+		return coding.compress(entry.Value)
+	}
+	if z, ok := store.Get(key + coding.suffix); ok && !z.StoredAt.Before(entry.StoredAt) {
+		return z.Value, nil
+	}
+	z, err := coding.compress(entry.Value)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Set(key+coding.suffix, z); err != nil {
+		fmt.Println("ERR", "cache", coding.name+":", err)
+	}
+	return z, nil
+}
+
+// etag returns the strong entity tag of a payload, each content coding gets its own tag
+func etag(b []byte, coding string) string {
+	sum := sha256.Sum256(b)
+	tag := hex.EncodeToString(sum[:16])
+	if coding != "" {
+		tag += "-" + coding
+	}
+	return `"` + tag + `"`
+}
+
+// acceptsCoding reports whether the client accepts the content coding according to Accept-Encoding
+func acceptsCoding(r *http.Request, name string) bool {
+	accepted := false
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		fields := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(fields[0]))
+		if coding != name && coding != "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				q, _ = strconv.ParseFloat(param[2:], 64)
+			}
+		}
+		if coding == name {
+			// An explicit entry wins over the wildcard
+			return q > 0
+		}
+		accepted = q > 0
+	}
+	return accepted
+}
+
+// notModified evaluates If-None-Match and If-Modified-Since against the entry
+func notModified(r *http.Request, tags []string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" {
+				return true
+			}
+			for _, tag := range tags {
+				if candidate == tag {
+					return true
+				}
+			}
+		}
+		// If-Modified-Since is ignored when If-None-Match is present
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		t, err := http.ParseTime(ims)
+		return err == nil && !lastModified.Truncate(time.Second).After(t)
+	}
+	return false
+}
+
+// writeEntry serves a cached entry with cache headers, answering 304 to conditional requests
+// and brotli or gzip to clients which accept it. An empty key serves an uncached entry.
+func writeEntry(w http.ResponseWriter, r *http.Request, key string, entry cache.Entry, cacheStatus string) {
+	tags := []string{etag(entry.Value, "")}
+	for _, coding := range contentCodings {
+		tags = append(tags, etag(entry.Value, coding.name))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Vary", "Accept-Encoding")
+	writeCacheHeaders(w, entry, cacheStatus)
+
+	body := entry.Value
+	tag := tags[0]
+	for i, coding := range contentCodings {
+		if !acceptsCoding(r, coding.name) {
+			continue
+		}
+		z, err := compressed(key, entry, coding)
+		if err != nil {
+			fmt.Println("ERR", coding.name+":", err)
+			continue
+		}
+		body = z
+		tag = tags[i+1]
+		w.Header().Set("Content-Encoding", coding.name)
+		break
+	}
+	w.Header().Set("ETag", tag)
+
+	if notModified(r, tags, entry.StoredAt) {
+		w.Header().Del("Content-Encoding")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.Write(body)
+}
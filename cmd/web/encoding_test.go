@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"gogithub/cache"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestAcceptsCoding(t *testing.T) {
+	tests := []struct {
+		header string
+		coding string
+		want   bool
+	}{
+		{"", "gzip", false},
+		{"gzip", "gzip", true},
+		{"GZIP", "gzip", true},
+		{"deflate, gzip", "gzip", true},
+		{"gzip;q=0", "gzip", false},
+		{"gzip;q=0.5", "gzip", true},
+		{"br", "gzip", false},
+		{"br", "br", true},
+		{"gzip, br;q=0", "br", false},
+		{"*", "gzip", true},
+		{"*;q=0", "gzip", false},
+		{"gzip;q=0, *", "gzip", false},
+		{"*, gzip;q=0", "gzip", false},
+		{"br, *;q=0.1", "gzip", true},
+		{"identity", "gzip", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/gh/summary", nil)
+		if tt.header != "" {
+			r.Header.Set("Accept-Encoding", tt.header)
+		}
+		if got := acceptsCoding(r, tt.coding); got != tt.want {
+			t.Errorf("acceptsCoding(%q, %s) = %v, want %v", tt.header, tt.coding, got, tt.want)
+		}
+	}
+}
+
+func TestWriteEntryEncoding(t *testing.T) {
+	store = cache.NewMemory(0, 0)
+	payload := []byte(`{"data":"` + strings.Repeat("octocat ", 100) + `"}`)
+	if err := setCached("test", payload); err != nil {
+		t.Fatal(err)
+	}
+	entry, _ := store.Get("test")
+
+	tests := []struct {
+		acceptEncoding string
+		encoding       string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"br", "br"},
+		{"gzip, deflate, br", "br"},
+		{"gzip, br;q=0", "gzip"},
+		{"identity", ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/gh/summary", nil)
+		r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+		w := httptest.NewRecorder()
+		writeEntry(w, r, "test", entry, "HIT")
+
+		if got := w.Header().Get("Content-Encoding"); got != tt.encoding {
+			t.Errorf("%q: Content-Encoding %q, want %q", tt.acceptEncoding, got, tt.encoding)
+			continue
+		}
+		if got, want := w.Header().Get("ETag"), etag(payload, tt.encoding); got != want {
+			t.Errorf("%q: ETag %s, want %s", tt.acceptEncoding, got, want)
+		}
+		var body io.Reader = w.Body
+		switch tt.encoding {
+		case "br":
+			body = brotli.NewReader(body)
+		case "gzip":
+			zr, err := gzip.NewReader(body)
+			if err != nil {
+				t.Fatalf("%q: %v", tt.acceptEncoding, err)
+			}
+			body = zr
+		}
+		b, err := ioutil.ReadAll(body)
+		if err != nil || !bytes.Equal(b, payload) {
+			t.Errorf("%q: body doesn't decode to the payload: %v", tt.acceptEncoding, err)
+		}
+
+		// Any of the variant tags revalidates, whatever the coding negotiated
+		r.Header.Set("If-None-Match", etag(payload, "br"))
+		w = httptest.NewRecorder()
+		writeEntry(w, r, "test", entry, "HIT")
+		if w.Code != http.StatusNotModified || w.Header().Get("Content-Encoding") != "" {
+			t.Errorf("%q: revalidation answered %d %q, want 304", tt.acceptEncoding, w.Code, w.Header().Get("Content-Encoding"))
+		}
+	}
+
+	// Both compressed copies were stored with the entry
+	for _, key := range []string{"test.br", "test.gz"} {
+		if _, ok := store.Get(key); !ok {
+			t.Errorf("%s wasn't stored", key)
+		}
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2024, 6, 1, 12, 0, 0, 500, time.UTC)
+	tags := []string{`"abc"`, `"abc-br"`, `"abc-gzip"`}
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{"no conditional headers", nil, false},
+		{"matching tag", map[string]string{"If-None-Match": `"abc"`}, true},
+		{"matching gzip tag", map[string]string{"If-None-Match": `"abc-gzip"`}, true},
+		{"matching brotli tag", map[string]string{"If-None-Match": `"abc-br"`}, true},
+		{"weak tag", map[string]string{"If-None-Match": `W/"abc"`}, true},
+		{"tag in list", map[string]string{"If-None-Match": `"old", "abc"`}, true},
+		{"other tag", map[string]string{"If-None-Match": `"old"`}, false},
+		{"wildcard", map[string]string{"If-None-Match": "*"}, true},
+		{"not modified since", map[string]string{"If-Modified-Since": "Sat, 01 Jun 2024 12:00:00 GMT"}, true},
+		{"modified since", map[string]string{"If-Modified-Since": "Sat, 01 Jun 2024 11:59:59 GMT"}, false},
+		{"invalid date", map[string]string{"If-Modified-Since": "yesterday"}, false},
+		{"tag wins over date", map[string]string{
+			"If-None-Match":     `"old"`,
+			"If-Modified-Since": "Sat, 01 Jun 2024 13:00:00 GMT",
+		}, false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/gh/summary", nil)
+		for k, v := range tt.headers {
+			r.Header.Set(k, v)
+		}
+		if got := notModified(r, tags, lastModified); got != tt.want {
+			t.Errorf("%s: notModified = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEtag(t *testing.T) {
+	a, b := etag([]byte("a"), ""), etag([]byte("b"), "")
+	if a == b {
+		t.Error("different payloads have the same tag")
+	}
+	if a != etag([]byte("a"), "") {
+		t.Error("tag isn't stable")
+	}
+	if gz := etag([]byte("a"), "gzip"); gz == a || gz[len(gz)-6:] != `-gzip"` {
+		t.Errorf("gzip tag = %s, want a distinct -gzip tag", gz)
+	}
+	if br := etag([]byte("a"), "br"); br == a || br[len(br)-4:] != `-br"` {
+		t.Errorf("brotli tag = %s, want a distinct -br tag", br)
+	}
+}
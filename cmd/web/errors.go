@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"gogithub/github"
+	"gogithub/model"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Machine readable error codes of the web API
+const (
+	codeInvalidRequest      = "invalid_request"
+	codeInvalidLogin        = "invalid_login"
+	codeNotFound            = "not_found"
+	codeMethodNotAllowed    = "method_not_allowed"
+	codeUserNotFound        = "user_not_found"
+	codeRateLimited         = "rate_limited"
+	codeUpstreamAuth        = "upstream_unauthorized"
+	codeUpstreamError       = "upstream_error"
+	codeUpstreamUnavailable = "upstream_unavailable"
+	codeDataNotReady        = "data_not_ready"
+	codeInternal            = "internal_error"
+	codePrivateNotAllowed   = "private_repos_not_allowed"
+	codeHistoryDisabled     = "history_disabled"
+	codeHistoryNotTracked   = "history_not_tracked"
+)
+
+// apiError = failed request, written as ResponsePayload with Error and Code
+type apiError struct {
+	Status     int
+	Code       string
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *apiError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// errorFromGithub maps a client error onto the web API error model
+func errorFromGithub(err error) *apiError {
+	var apiErr *apiError
+	var notFound *github.NotFoundError
+	var rateLimit *github.RateLimitError
+	var auth *github.AuthError
+	var qualifier *github.QualifierError
+	var httpErr *github.HTTPError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr
+	case errors.As(err, &notFound):
+		return &apiError{Status: http.StatusNotFound, Code: codeUserNotFound, Message: "User not found"}
+	case errors.As(err, &rateLimit):
+		wait := rateLimit.Wait()
+		if wait <= 0 {
+			// GitHub didn't tell, secondary limits usually clear within a minute
+			wait = time.Minute
+		}
+		return &apiError{
+			Status:     http.StatusTooManyRequests,
+			Code:       codeRateLimited,
+			Message:    "Rate limited by GitHub",
+			RetryAfter: wait,
+		}
+	case errors.As(err, &qualifier):
+		return &apiError{Status: http.StatusBadRequest, Code: codeInvalidRequest, Message: qualifier.Error()}
+	case errors.As(err, &auth):
+		return &apiError{Status: http.StatusBadGateway, Code: codeUpstreamAuth, Message: "GitHub authorization failed"}
+	case errors.As(err, &httpErr) && httpErr.StatusCode >= 500,
+		errors.As(err, &netErr),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, context.Canceled):
+		return &apiError{Status: http.StatusServiceUnavailable, Code: codeUpstreamUnavailable, Message: "GitHub is unavailable"}
+	}
+	return &apiError{Status: http.StatusBadGateway, Code: codeUpstreamError, Message: "Unexpected response from GitHub"}
+}
+
+// writeError writes the error envelope, Retry-After is rounded up to whole seconds
+func writeError(w http.ResponseWriter, e *apiError) {
+	if e.RetryAfter > 0 {
+		secs := int((e.RetryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(secs))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(e.Status)
+	b, _ := json.Marshal(model.ResponsePayload{
+		Error: e.Message,
+		Code:  e.Code,
+	})
+	w.Write(b)
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"gogithub/config"
+	"gogithub/github"
+	"gogithub/model"
+	"gogithub/snapshot"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultHistoryWindow = "30d"
+
+// snapshots records every crawl for the history endpoints, nil when SNAPSHOT_DIR is empty
+var snapshots *snapshot.Store
+
+func openSnapshots() *snapshot.Store {
+	dir := config.SnapshotDir()
+	if dir == "" {
+		log.Println("SNAPSHOT_DIR is empty, history is disabled")
+		return nil
+	}
+	s, err := snapshot.Open(dir)
+	if err != nil {
+		log.Fatal("Failed to open snapshot store: ", err)
+	}
+	s.Retention = config.SnapshotRetention()
+	return s
+}
+
+// tracked reports whether login is ranked by the latest top stars crawl, only their profiles
+// are recorded so arbitrary usernames can't grow the store
+func tracked(login string) bool {
+	latest, ok, err := snapshots.LatestTopStars(leaderboard.Name)
+	if err != nil {
+		fmt.Println("ERR tracked:", err)
+	}
+	if !ok {
+		return false
+	}
+	for _, dev := range latest.Devs {
+		if strings.EqualFold(dev.Login, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordProfile keeps the counts of a profile crawl of a tracked developer, only the default
+// repository selection is recorded so the points of a user stay comparable
+func recordProfile(username string, opts github.FetchOptions, data *github.RepoData) {
+	if snapshots == nil || !opts.DefaultSelection() || !tracked(username) {
+		return
+	}
+	if err := snapshots.RecordProfile(username, snapshot.NewProfilePoint(time.Now(), data)); err != nil {
+		fmt.Println("ERR recordProfile:", err)
+	}
+}
+
+// topStarsCrawl = cached top stars crawl, every dev of the segment with the previously recorded
+// crawl, it's ranked by the score options of each request with rankTopStars
+type topStarsCrawl struct {
+	At       time.Time          `json:"at"`
+	Devs     []github.DevStar   `json:"devs"`
+	Previous *snapshot.TopStars `json:"previous"`
+}
+
+// recordTopStars records a top stars crawl and returns it with the previously recorded one,
+// crawls older than SNAPSHOT_RETENTION are pruned on the way
+func recordTopStars(devs []github.DevStar) topStarsCrawl {
+	crawl := topStarsCrawl{At: time.Now(), Devs: devs}
+	if snapshots == nil {
+		return crawl
+	}
+	prev, ok, err := snapshots.LatestTopStars(leaderboard.Name)
+	if err != nil {
+		fmt.Println("ERR recordTopStars: previous crawl:", err)
+	}
+	if ok {
+		crawl.Previous = &prev
+	}
+	if err := snapshots.RecordTopStars(leaderboard.Name, snapshot.NewTopStars(crawl.At, devs)); err != nil {
+		fmt.Println("ERR recordTopStars:", err)
+	}
+	if err := snapshots.Prune(crawl.At); err != nil {
+		fmt.Println("ERR recordTopStars: prune:", err)
+	}
+	return crawl
+}
+
+// rankTopStars ranks the crawl by opts, the rank movement of every dev is computed
+// against the previous crawl ranked the same way
+func rankTopStars(crawl topStarsCrawl, opts github.ScoreOptions) TopStarsPayload {
+	devs := github.RankDevStars(crawl.Devs, opts)
+	payload := TopStarsPayload{
+		Leaderboard: leaderboard.Name,
+		ScoreBy:     opts.By,
+		Devs:        devs,
+		New:         []snapshot.DevEntry{},
+		Dropped:     []snapshot.DevEntry{},
+	}
+	if opts.By == github.ScoreComposite {
+		payload.Weights = &opts.Weights
+	}
+	if crawl.Previous == nil {
+		return payload
+	}
+
+	prev := snapshot.Rerank(*crawl.Previous, opts)
+	trend := snapshot.CompareTopStars(prev, snapshot.NewTopStars(crawl.At, devs))
+	// trend.Devs keeps the order of devs
+	for i, move := range trend.Devs {
+		devs[i].PreviousRank = move.PreviousRank
+		devs[i].StarsDelta = move.StarsDelta
+	}
+	payload.PreviousCrawl = &prev.At
+	payload.New = trend.New
+	payload.Dropped = trend.Dropped
+	return payload
+}
+
+// rerankTopStars ranks every crawl by opts
+func rerankTopStars(snaps []snapshot.TopStars, opts github.ScoreOptions) []snapshot.TopStars {
+	for i := range snaps {
+		snaps[i] = snapshot.Rerank(snaps[i], opts)
+	}
+	return snaps
+}
+
+// historySince reads the ?window= of a history request, e.g. 30d, 12w or 72h
+func historySince(r *http.Request) (time.Time, error) {
+	window := r.URL.Query().Get("window")
+	if window == "" {
+		window = defaultHistoryWindow
+	}
+	d, err := snapshot.ParseWindow(window)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(-d), nil
+}
+
+// historyRequest checks history is enabled and reads the window, it writes the error otherwise
+func historyRequest(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	if snapshots == nil {
+		writeError(w, &apiError{Status: http.StatusNotFound, Code: codeHistoryDisabled, Message: "History is disabled on this server"})
+		return time.Time{}, false
+	}
+	since, err := historySince(r)
+	if err != nil {
+		writeParamError(w, err)
+		return time.Time{}, false
+	}
+	return since, true
+}
+
+func writeHistory(w http.ResponseWriter, data interface{}, err error) {
+	if err != nil {
+		fmt.Println("ERR history:", err.Error())
+		writeError(w, &apiError{Status: http.StatusInternalServerError, Code: codeInternal, Message: "Failed to read history"})
+		return
+	}
+	b, _ := json.Marshal(model.ResponsePayload{
+		Data: data,
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(b)
+}
+
+// handleProfileHistory serves the star, repository and fork deltas of a user, e.g. ?window=30d
+func handleProfileHistory(w http.ResponseWriter, r *http.Request) {
+	since, ok := historyRequest(w, r)
+	if !ok {
+		return
+	}
+	username := pathParam(r, "login")
+	points, err := snapshots.Profiles(username, since)
+	// Only developers ranked by the top stars crawl are recorded, an empty history of anyone else
+	// doesn't mean their counts didn't change
+	if err == nil && len(points) == 0 && !tracked(username) {
+		writeError(w, &apiError{Status: http.StatusNotFound, Code: codeHistoryNotTracked, Message: "History isn't recorded for this user"})
+		return
+	}
+	writeHistory(w, snapshot.NewProfileTrend(username, points), err)
+}
+
+// handleTopStarsHistory serves the rank changes between the oldest and latest top stars crawl of the window
+func handleTopStarsHistory(w http.ResponseWriter, r *http.Request) {
+	since, ok := historyRequest(w, r)
+	if !ok {
+		return
+	}
+	opts, err := scoreOptions(r.URL.Query())
+	if err != nil {
+		writeParamError(w, err)
+		return
+	}
+	snaps, err := snapshots.TopStars(leaderboard.Name, since)
+	snaps = rerankTopStars(snaps, opts)
+	var first, last snapshot.TopStars
+	if len(snaps) > 0 {
+		first, last = snaps[0], snaps[len(snaps)-1]
+	}
+	writeHistory(w, snapshot.CompareTopStars(first, last), err)
+}
+
+// handleDevHistory serves the stars and rank of a developer in every top stars crawl of the window
+func handleDevHistory(w http.ResponseWriter, r *http.Request) {
+	since, ok := historyRequest(w, r)
+	if !ok {
+		return
+	}
+	opts, err := scoreOptions(r.URL.Query())
+	if err != nil {
+		writeParamError(w, err)
+		return
+	}
+	username := pathParam(r, "login")
+	snaps, err := snapshots.TopStars(leaderboard.Name, since)
+	writeHistory(w, snapshot.DevSeries(rerankTopStars(snaps, opts), username), err)
+}
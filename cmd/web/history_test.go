@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"gogithub/github"
+	"gogithub/model"
+	"gogithub/snapshot"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestHandleProfileHistoryTracking(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if snapshots, err = snapshot.Open(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { snapshots = nil }()
+	leaderboard = &github.Leaderboard{Name: "Test"}
+
+	now := time.Now()
+	crawl := snapshot.TopStars{At: now, Devs: []snapshot.DevEntry{{Login: "alice", Rank: 1}, {Login: "bob", Rank: 2}}}
+	if err := snapshots.RecordTopStars(leaderboard.Name, crawl); err != nil {
+		t.Fatal(err)
+	}
+	// Recorded while carol was still ranked
+	for _, login := range []string{"alice", "carol"} {
+		if err := snapshots.RecordProfile(login, snapshot.ProfilePoint{At: now.Add(-time.Hour), Stars: 10}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		login  string
+		status int
+		code   string
+		points int
+	}{
+		{"alice", http.StatusOK, "", 1},
+		{"Alice", http.StatusOK, "", 1},
+		{"bob", http.StatusOK, "", 0},
+		{"carol", http.StatusOK, "", 1},
+		{"dave", http.StatusNotFound, codeHistoryNotTracked, 0},
+	}
+	rt := &router{}
+	rt.handle(http.MethodGet, "/gh/history/profile/{login}", handleProfileHistory)
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		rt.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/gh/history/profile/"+tt.login, nil))
+		if w.Code != tt.status {
+			t.Errorf("%s: status %d, want %d: %s", tt.login, w.Code, tt.status, w.Body.String())
+			continue
+		}
+		var resp struct {
+			model.ResponsePayload
+			Data *snapshot.ProfileTrend `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: %v", tt.login, err)
+		}
+		if resp.Code != tt.code {
+			t.Errorf("%s: code %q, want %q", tt.login, resp.Code, tt.code)
+		}
+		if tt.status == http.StatusOK && len(resp.Data.Points) != tt.points {
+			t.Errorf("%s: %d points, want %d", tt.login, len(resp.Data.Points), tt.points)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"gogithub/github"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// fetchOptions reads the profile and repository options,
+// e.g. ?top=10&top_by=pushed&languages=true&include_forks=true&affiliations=owner,organization_member
+func fetchOptions(query url.Values) (github.FetchOptions, error) {
+	opts := github.FetchOptions{
+		TopBy: query.Get("top_by"),
+	}
+	if err := queryInts(query, map[string]*int{"top": &opts.TopN}); err != nil {
+		return opts, err
+	}
+	err := queryBools(query, map[string]*bool{
+		"languages":        &opts.Languages,
+		"include_forks":    &opts.IncludeForks,
+		"exclude_archived": &opts.ExcludeArchived,
+		"include_private":  &opts.IncludePrivate,
+	})
+	if err != nil {
+		return opts, err
+	}
+	if v := query.Get("affiliations"); v != "" {
+		opts.Affiliations = strings.Split(v, ",")
+	}
+	if opts.IncludePrivate && !allowPrivateRepos {
+		return opts, &apiError{
+			Status:  http.StatusForbidden,
+			Code:    codePrivateNotAllowed,
+			Message: "Private repositories are not available on this server",
+		}
+	}
+	return opts, opts.Validate()
+}
+
+// repoFilterValues = options which select the repositories, part of every cache key of user data
+func repoFilterValues(opts github.FetchOptions) url.Values {
+	query := url.Values{}
+	query.Set("include_forks", strconv.FormatBool(opts.IncludeForks))
+	query.Set("exclude_archived", strconv.FormatBool(opts.ExcludeArchived))
+	query.Set("include_private", strconv.FormatBool(opts.IncludePrivate))
+	query.Set("affiliations", strings.ToLower(strings.Join(opts.Affiliations, ",")))
+	return query
+}
+
+// scoreOptions reads the top stars ranking, missing parameters fall back to the configured defaults,
+// e.g. ?score_by=composite&weights=stars:1,forks:2,followers:0.5&min_followers=100
+func scoreOptions(query url.Values) (github.ScoreOptions, error) {
+	opts := defaultScore
+	if v := query.Get("score_by"); v != "" {
+		opts.By = v
+	}
+	err := queryInts(query, map[string]*int{
+		"min_stars":     &opts.MinStars,
+		"min_forks":     &opts.MinForks,
+		"min_followers": &opts.MinFollowers,
+		"min_repos":     &opts.MinRepos,
+	})
+	if err != nil {
+		return opts, err
+	}
+	if v := query.Get("weights"); v != "" {
+		if opts.Weights, err = github.ParseWeights(v); err != nil {
+			return opts, err
+		}
+	}
+	return opts, opts.Validate()
+}
+
+// queryInts parses the named integer query parameters which are present into dst
+func queryInts(query url.Values, dst map[string]*int) error {
+	for name, n := range dst {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%s must be a number", name)
+		}
+		*n = parsed
+	}
+	return nil
+}
+
+// queryBools parses the named boolean query parameters which are present into dst
+func queryBools(query url.Values, dst map[string]*bool) error {
+	for name, b := range dst {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false", name)
+		}
+		*b = parsed
+	}
+	return nil
+}
+
+// writeParamError answers 400 unless the error already tells its status
+func writeParamError(w http.ResponseWriter, err error) {
+	if apiErr, ok := err.(*apiError); ok {
+		writeError(w, apiErr)
+		return
+	}
+	writeError(w, &apiError{Status: http.StatusBadRequest, Code: codeInvalidRequest, Message: err.Error()})
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gogithub/github"
+	"gogithub/model"
+	"net/http"
+	"net/url"
+)
+
+// fetchRepos crawls every repository of the user and caches the list,
+// the listing options are applied per request on top of it
+func fetchRepos(ctx context.Context, username string, opts github.FetchOptions) ([]byte, error) {
+	repos, err := ghClient.FetchRepositories(ctx, username, opts)
+	if err != nil {
+		return nil, err
+	}
+	b, _ := json.Marshal(repos)
+	if err := store.Set(reposCacheKey(username, opts), b); err != nil {
+		fmt.Println("ERR fetchRepos: cache:", err)
+	}
+	return b, nil
+}
+
+// repoListOptions reads the listing options, e.g. ?sort=pushed&order=desc&language=Go&min_stars=10&page=2&per_page=50
+func repoListOptions(query url.Values) (github.RepoListOptions, error) {
+	opts := github.RepoListOptions{
+		Sort:     query.Get("sort"),
+		Order:    query.Get("order"),
+		Language: query.Get("language"),
+	}
+	err := queryInts(query, map[string]*int{
+		"min_stars": &opts.MinStars,
+		"page":      &opts.Page,
+		"per_page":  &opts.PerPage,
+	})
+	if err != nil {
+		return opts, err
+	}
+	return opts, opts.Validate()
+}
+
+func handleGithubRepos(w http.ResponseWriter, r *http.Request) {
+	username := pathParam(r, "login")
+
+	listOpts, err := repoListOptions(r.URL.Query())
+	if err != nil {
+		writeParamError(w, err)
+		return
+	}
+	opts, err := fetchOptions(r.URL.Query())
+	if err != nil {
+		writeParamError(w, err)
+		return
+	}
+
+	entry, cacheStatus, err := cachedUserData(r.Context(), reposCacheKey(username, opts), func(ctx context.Context) ([]byte, error) {
+		return fetchRepos(ctx, username, opts)
+	})
+	if err != nil {
+		fmt.Println("ERR handleGithubRepos:", err.Error())
+		writeError(w, errorFromGithub(err))
+		return
+	}
+
+	var repos []github.Repository
+	if err := json.Unmarshal(entry.Value, &repos); err != nil {
+		fmt.Println("ERR handleGithubRepos: cache:", err.Error())
+		writeError(w, &apiError{Status: http.StatusInternalServerError, Code: codeInternal, Message: "Corrupted cache entry"})
+		return
+	}
+
+	b, _ := json.Marshal(model.ResponsePayload{
+		Data: github.ListRepositories(repos, listOpts),
+	})
+	w.Header().Set("Content-Type", "application/json")
+	writeCacheHeaders(w, entry, cacheStatus)
+	w.Write(b)
+}
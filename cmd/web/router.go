@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// apiVersionPrefix is prepended to every route, the unversioned paths are kept as legacy aliases
+const apiVersionPrefix = "/v1"
+
+// GitHub logins: alphanumeric characters or single hyphens, not starting or ending with a hyphen.
+// The pattern alone would let hyphenated logins grow past the length limit.
+var loginRe = regexp.MustCompile(`^[A-Za-z0-9](?:-?[A-Za-z0-9]){0,38}$`)
+
+// maxLoginLength = longest login GitHub accepts
+const maxLoginLength = 39
+
+func validLogin(login string) bool {
+	return len(login) <= maxLoginLength && loginRe.MatchString(login)
+}
+
+// paramRule validates a path parameter before the handler runs
+type paramRule struct {
+	valid func(string) bool
+	code  string
+}
+
+// paramRules by parameter name
+var paramRules = map[string]paramRule{
+	"login": {valid: validLogin, code: codeInvalidLogin},
+}
+
+type paramsKey struct{}
+
+// route = method + path pattern, a `{name}` segment matches a single path segment
+type route struct {
+	method   string
+	segments []string
+	handler  http.HandlerFunc
+}
+
+// router dispatches requests by method and path, unknown paths are answered with a JSON 404
+// and known paths requested with the wrong method with a JSON 405
+type router struct {
+	routes []route
+}
+
+// handle registers the handler under the versioned pattern and its legacy alias
+func (rt *router) handle(method, pattern string, handler http.HandlerFunc) {
+	for _, p := range []string{apiVersionPrefix + pattern, pattern} {
+		rt.routes = append(rt.routes, route{
+			method:   method,
+			segments: splitPath(p),
+			handler:  handler,
+		})
+	}
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := splitPath(r.URL.Path)
+	var allowed []string
+	for _, rte := range rt.routes {
+		params, ok := rte.match(segments)
+		if !ok {
+			continue
+		}
+		if rte.method != r.Method && !(rte.method == http.MethodGet && r.Method == http.MethodHead) {
+			allowed = append(allowed, rte.method)
+			continue
+		}
+		for name, value := range params {
+			if rule, ok := paramRules[name]; ok && !rule.valid(value) {
+				writeError(w, &apiError{Status: http.StatusBadRequest, Code: rule.code, Message: "Invalid " + name})
+				return
+			}
+		}
+		ctx := context.WithValue(r.Context(), paramsKey{}, params)
+		rte.handler(w, r.WithContext(ctx))
+		return
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", allowHeader(allowed))
+		writeError(w, &apiError{Status: http.StatusMethodNotAllowed, Code: codeMethodNotAllowed, Message: "Method " + r.Method + " not allowed"})
+		return
+	}
+	writeError(w, &apiError{Status: http.StatusNotFound, Code: codeNotFound, Message: "Not found"})
+}
+
+// match compares the path segments with the route pattern and extracts its parameters
+func (rte route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(rte.segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, seg := range rte.segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if segments[i] == "" {
+				return nil, false
+			}
+			params[seg[1:len(seg)-1]] = segments[i]
+			continue
+		}
+		if seg != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// pathParam returns the named path parameter of the matched route
+func pathParam(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsKey{}).(map[string]string)
+	return params[name]
+}
+
+// splitPath splits an URL path into segments, a trailing slash is ignored
+func splitPath(path string) []string {
+	path = strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/")
+	return strings.Split(path, "/")
+}
+
+func allowHeader(methods []string) string {
+	seen := make(map[string]bool)
+	var allow []string
+	for _, m := range methods {
+		if !seen[m] {
+			seen[m] = true
+			allow = append(allow, m)
+		}
+		if m == http.MethodGet && !seen[http.MethodHead] {
+			seen[http.MethodHead] = true
+			allow = append(allow, http.MethodHead)
+		}
+	}
+	sort.Strings(allow)
+	return strings.Join(allow, ", ")
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"gogithub/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testRouter answers every matched route with the route name and its login parameter
+func testRouter() *router {
+	rt := &router{}
+	for _, pattern := range []string{"/gh/summary", "/gh/profile/{login}", "/gh/profile/{login}/repos"} {
+		pattern := pattern
+		rt.handle(http.MethodGet, pattern, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(pattern + " " + pathParam(r, "login")))
+		})
+	}
+	return rt
+}
+
+func TestRouter(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		status int
+		// body of a matched route, error code otherwise
+		want  string
+		allow string
+	}{
+		{http.MethodGet, "/v1/gh/summary", http.StatusOK, "/gh/summary ", ""},
+		{http.MethodGet, "/gh/summary", http.StatusOK, "/gh/summary ", ""},
+		{http.MethodGet, "/gh/summary/", http.StatusOK, "/gh/summary ", ""},
+		{http.MethodGet, "/v1/gh/profile/octocat", http.StatusOK, "/gh/profile/{login} octocat", ""},
+		{http.MethodGet, "/gh/profile/octo-cat", http.StatusOK, "/gh/profile/{login} octo-cat", ""},
+		{http.MethodGet, "/gh/profile/octocat/repos", http.StatusOK, "/gh/profile/{login}/repos octocat", ""},
+		{http.MethodGet, "/v1/gh/profile/octocat/repos", http.StatusOK, "/gh/profile/{login}/repos octocat", ""},
+		{http.MethodHead, "/gh/summary", http.StatusOK, "/gh/summary ", ""},
+
+		{http.MethodGet, "/", http.StatusNotFound, codeNotFound, ""},
+		{http.MethodGet, "/gh/unknown", http.StatusNotFound, codeNotFound, ""},
+		{http.MethodGet, "/v2/gh/summary", http.StatusNotFound, codeNotFound, ""},
+		{http.MethodGet, "/gh/profile/", http.StatusNotFound, codeNotFound, ""},
+		{http.MethodGet, "/gh/profile/a/b", http.StatusNotFound, codeNotFound, ""},
+		{http.MethodPost, "/gh/unknown", http.StatusNotFound, codeNotFound, ""},
+
+		{http.MethodPost, "/gh/summary", http.StatusMethodNotAllowed, codeMethodNotAllowed, "GET, HEAD"},
+		{http.MethodPut, "/v1/gh/profile/octocat", http.StatusMethodNotAllowed, codeMethodNotAllowed, "GET, HEAD"},
+
+		{http.MethodGet, "/gh/profile/-octocat", http.StatusBadRequest, codeInvalidLogin, ""},
+		{http.MethodGet, "/gh/profile/octocat-", http.StatusBadRequest, codeInvalidLogin, ""},
+		{http.MethodGet, "/gh/profile/octo--cat", http.StatusBadRequest, codeInvalidLogin, ""},
+		{http.MethodGet, "/gh/profile/octo_cat/repos", http.StatusBadRequest, codeInvalidLogin, ""},
+		{http.MethodGet, "/gh/profile/" + strings.Repeat("a", 39), http.StatusOK, "/gh/profile/{login} " + strings.Repeat("a", 39), ""},
+		{http.MethodGet, "/gh/profile/" + strings.Repeat("a", 40), http.StatusBadRequest, codeInvalidLogin, ""},
+		// Single hyphens match the pattern whatever the length
+		{http.MethodGet, "/gh/profile/" + strings.Repeat("a-", 20) + "a", http.StatusBadRequest, codeInvalidLogin, ""},
+	}
+
+	rt := testRouter()
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		rt.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		if w.Code != tt.status {
+			t.Errorf("%s %s: status %d, want %d", tt.method, tt.path, w.Code, tt.status)
+			continue
+		}
+		if got := w.Header().Get("Allow"); got != tt.allow {
+			t.Errorf("%s %s: Allow %q, want %q", tt.method, tt.path, got, tt.allow)
+		}
+		if tt.status == http.StatusOK {
+			if w.Body.String() != tt.want {
+				t.Errorf("%s %s: routed to %q, want %q", tt.method, tt.path, w.Body.String(), tt.want)
+			}
+			continue
+		}
+		var payload model.ResponsePayload
+		if err := json.Unmarshal(w.Body.Bytes(), &payload); err != nil {
+			t.Errorf("%s %s: body isn't JSON: %v", tt.method, tt.path, err)
+			continue
+		}
+		if payload.Code != tt.want || payload.Error == "" {
+			t.Errorf("%s %s: error %+v, want code %s", tt.method, tt.path, payload, tt.want)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s %s: Content-Type %q", tt.method, tt.path, ct)
+		}
+	}
+}
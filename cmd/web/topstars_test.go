@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"gogithub/cache"
+	"gogithub/github"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+)
+
+// recordingCache remembers the keys which were set, entries under aged are reported an hour older
+type recordingCache struct {
+	cache.Cache
+	keys map[string]bool
+	aged string
+}
+
+func (c *recordingCache) Get(key string) (cache.Entry, bool) {
+	entry, ok := c.Cache.Get(key)
+	if key == c.aged {
+		entry.StoredAt = entry.StoredAt.Add(-time.Hour)
+	}
+	return entry, ok
+}
+
+func (c *recordingCache) Set(key string, value []byte) error {
+	c.keys[key] = true
+	return c.Cache.Set(key, value)
+}
+
+func testDevStar(login string, stars, repos int) github.DevStar {
+	dev := &github.SummaryDev{}
+	dev.Node.Login = login
+	return github.DevStar{Stars: stars, Repos: repos, Dev: dev}
+}
+
+func TestHandleTopStarsCaching(t *testing.T) {
+	// The crawl is older than the ranking cached from it
+	rec := &recordingCache{Cache: cache.NewMemory(0, 0), keys: map[string]bool{}, aged: cacheTypeTopStar}
+	store = rec
+	leaderboard = &github.Leaderboard{Name: "Test"}
+	snapshots = nil
+	refreshers = map[string]*refresher{cacheTypeTopStar: newRefresher(cacheTypeTopStar, cacheTTLTopStar)}
+	defaultScore = github.ScoreOptions{MinStars: 10}
+	if err := defaultScore.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(query string) (*httptest.ResponseRecorder, TopStarsPayload) {
+		w := httptest.NewRecorder()
+		handleTopStars(w, httptest.NewRequest(http.MethodGet, "/gh/topstars"+query, nil))
+		var resp struct {
+			Data TopStarsPayload `json:"data"`
+		}
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("%s: %v", query, err)
+			}
+		}
+		return w, resp.Data
+	}
+
+	if w, _ := get(""); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("before the first crawl: %d, want 503", w.Code)
+	}
+
+	crawl := topStarsCrawl{Devs: []github.DevStar{
+		testDevStar("alice", 50, 10),
+		testDevStar("bob", 40, 1),
+		testDevStar("carol", 5, 1),
+	}}
+	b, _ := json.Marshal(crawl)
+	store.Set(cacheTypeTopStar, b)
+	rec.keys = map[string]bool{}
+
+	tests := []struct {
+		query  string
+		status int
+		logins []string
+	}{
+		{"", http.StatusOK, []string{"alice", "bob"}},
+		{"", http.StatusOK, []string{"alice", "bob"}},
+		{"?score_by=stars_per_repo", http.StatusOK, []string{"bob", "alice"}},
+		{"?min_stars=0", http.StatusOK, []string{"alice", "bob", "carol"}},
+		{"?min_stars=0&unused=1", http.StatusOK, []string{"alice", "bob", "carol"}},
+		{"?score_by=composite&weights=stars:0", http.StatusBadRequest, nil},
+		{"?score_by=composite&weights=stars:NaN", http.StatusBadRequest, nil},
+	}
+	crawlEntry, _ := store.Get(cacheTypeTopStar)
+	lastModified := crawlEntry.StoredAt.UTC().Format(http.TimeFormat)
+	for _, tt := range tests {
+		w, payload := get(tt.query)
+		if w.Code != tt.status {
+			t.Errorf("%q: status %d, want %d: %s", tt.query, w.Code, tt.status, w.Body.String())
+			continue
+		}
+		// Rankings computed and served from the cache alike are as old as the crawl
+		if got := w.Header().Get("Last-Modified"); tt.status == http.StatusOK && got != lastModified {
+			t.Errorf("%q: Last-Modified %s, want the crawl's %s", tt.query, got, lastModified)
+		}
+		var logins []string
+		for _, dev := range payload.Devs {
+			logins = append(logins, dev.Dev.Node.Login)
+		}
+		if len(logins) != len(tt.logins) {
+			t.Errorf("%q: ranked %v, want %v", tt.query, logins, tt.logins)
+			continue
+		}
+		for i := range logins {
+			if logins[i] != tt.logins[i] {
+				t.Errorf("%q: ranked %v, want %v", tt.query, logins, tt.logins)
+				break
+			}
+		}
+	}
+
+	// Only the default ranking is cached, whatever the query
+	var keys []string
+	for key := range rec.keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) != 1 || keys[0] != topStarsRankedKey {
+		t.Errorf("cached keys %v, want only %s", keys, topStarsRankedKey)
+	}
+	for _, key := range keys {
+		if !isJobKey(key) {
+			t.Errorf("%s is evictable", key)
+		}
+	}
+}
@@ -1,124 +1,81 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"gogithub/cache"
 	"gogithub/config"
 	"gogithub/github"
 	"gogithub/model"
+	"gogithub/snapshot"
 	"log"
 	"net/http"
-	"strings"
+	"strconv"
 	"time"
 )
 
-var cacheSummary []byte
-var lastCache time.Time
-var cacheTopStar []byte
-var lastCacheTopStar time.Time
-var cacheJobs chan string
-
-const (
-	cacheHours        = 24
-	cacheHoursTopStar = 24 * 14
-
-	cacheTypeSummary string = "summary"
-	cacheTypeTopStar string = "topstar"
-)
+var store cache.Cache
+var refreshers map[string]*refresher
+var ghClient *github.Client
+var leaderboard *github.Leaderboard
+var profileGroup cache.Group
+var profileCacheTTL time.Duration
+var allowPrivateRepos bool
+var defaultScore github.ScoreOptions
 
 // ProfilePayload for profile response payload
 type ProfilePayload struct {
-	Username      string                     `json:"username"`
-	StarCount     int                        `json:"star_count"`
-	RepoCount     int                        `json:"repo_count"`
-	ForkCount     int                        `json:"fork_count"`
-	LanguageCount int                        `json:"language_count"`
-	LanguageMap   map[string]int32           `json:"language_map"`
-	AvatarURL     string                     `json:"avatar_url"`
-	TopRepo       *github.UserRepositoryEdge `json:"top_repo"`
-}
-
-func checkCache(lastCache time.Time, cacheHours int, cacheBytes []byte) bool {
-	hoursElapsed := time.Since(lastCache).Hours()
-	return hoursElapsed < float64(cacheHours) && len(cacheBytes) != 0
-}
-
-func processMemoryCache(jobType string) {
-	if jobType == cacheTypeSummary {
-		if checkCache(lastCache, cacheHours, cacheSummary) {
-			return
-		}
-		data, err := github.FetchTopUserSummary()
-		if err != nil {
-			fmt.Println("ERR", err)
-			return
-		}
-		// Prevents caching rate limit error data
-		if data["message"] != nil {
-			fmt.Println("ERR", data["message"])
-			return
-		}
-		b, _ := json.Marshal(data)
-		cacheSummary = b
-		lastCache = time.Now()
-		fmt.Println("summary cache is now updated!")
-	} else if jobType == cacheTypeTopStar {
-		if checkCache(lastCacheTopStar, cacheHoursTopStar, cacheTopStar) {
-			return
-		}
-		dataTopStar, err := github.FetchAllStars()
-		if err != nil {
-			fmt.Println("ERR", "FetchAllStars:", err)
-			return
-		}
-		// Prevents caching empty result
-		if len(dataTopStar) == 0 {
-			fmt.Println("ERR", "FetchAllStars: Empty result")
-			return
-		}
-		data := &model.ResponsePayload{
-			Data: dataTopStar,
-		}
-		b, _ := json.Marshal(data)
-		cacheTopStar = b
-		lastCacheTopStar = time.Now()
-		fmt.Println("topstar cache is now updated!")
-	}
+	Username string `json:"username"`
+	// OwnerType = user or organization, MemberCount is only set for organizations
+	OwnerType     string           `json:"owner_type"`
+	MemberCount   int              `json:"member_count,omitempty"`
+	StarCount     int              `json:"star_count"`
+	RepoCount     int              `json:"repo_count"`
+	ForkCount     int              `json:"fork_count"`
+	LanguageCount int              `json:"language_count"`
+	LanguageMap   map[string]int32 `json:"language_map"`
+	// Languages = byte-weighted breakdown, only requested with ?languages=true
+	Languages []github.LanguageShare `json:"languages,omitempty"`
+	AvatarURL string                 `json:"avatar_url"`
+	TopRepos  []github.Repository    `json:"top_repos"`
+	// Deprecated: most starred repository, kept for existing clients, use TopRepos
+	TopRepo *github.UserRepositoryEdge `json:"top_repo"`
 }
 
-func checkMemoryCache(cacheJobs <-chan string) {
-	for job := range cacheJobs {
-		processMemoryCache(job)
-	}
+// TopStarsPayload for top stars response payload, PreviousCrawl is null when there is no
+// recorded crawl to compare with, New and Dropped are empty then
+type TopStarsPayload struct {
+	Leaderboard string `json:"leaderboard"`
+	// ScoreBy = metric the devs are ranked by, Weights are only set for the composite score
+	ScoreBy       string              `json:"score_by"`
+	Weights       *github.Weights     `json:"weights,omitempty"`
+	PreviousCrawl *time.Time          `json:"previous_crawl"`
+	Devs          []github.DevStar    `json:"devs"`
+	New           []snapshot.DevEntry `json:"new"`
+	Dropped       []snapshot.DevEntry `json:"dropped"`
 }
 
-func handleGithubProfile(w http.ResponseWriter, r *http.Request) {
-	urlPath := r.URL.Path
-	urlPath = strings.TrimPrefix(urlPath, "/")
-	urlSeg := strings.SplitN(urlPath, "/", 3)
-	username := urlSeg[2]
-
-	data, err := github.FetchAllRepos(username)
-
+// fetchProfile crawls the profile and caches the response payload
+func fetchProfile(ctx context.Context, username string, opts github.FetchOptions) ([]byte, error) {
+	data, err := ghClient.FetchAllRepos(ctx, username, opts)
 	if err != nil {
-		fmt.Println("ERR handleGitHubProfile:", err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		payload := model.ResponsePayload{
-			Error: "Error fetch profile",
-		}
-		b, _ := json.Marshal(payload)
-		w.Write(b)
-		return
+		return nil, err
 	}
 
 	profilePayload := ProfilePayload{
 		Username:      username,
+		OwnerType:     data.OwnerType,
+		MemberCount:   data.MemberCount,
 		AvatarURL:     data.AvatarURL,
 		StarCount:     data.StarCount,
 		RepoCount:     data.RepoCount,
 		ForkCount:     data.ForkCount,
 		LanguageCount: len(data.LanguageMap),
 		LanguageMap:   data.LanguageMap,
+		Languages:     data.Languages,
+		TopRepos:      data.TopRepos,
 		TopRepo:       data.TopRepo,
 	}
 
@@ -126,53 +83,255 @@ func handleGithubProfile(w http.ResponseWriter, r *http.Request) {
 		Data: profilePayload,
 	}
 
+	recordProfile(username, opts, data)
+
 	b, _ := json.Marshal(payload)
+	if err := setCached(profileCacheKey(username, opts), b); err != nil {
+		fmt.Println("ERR fetchProfile: cache:", err)
+	}
+	return b, nil
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(b)
+// canServeStale reports whether a failed refresh may fall back to the stale entry,
+// a user that no longer exists or a caller that went away may not
+func canServeStale(err error) bool {
+	var notFound *github.NotFoundError
+	return !errors.As(err, &notFound) &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, context.DeadlineExceeded)
+}
+
+// cachedUserData returns the cached entry while it's fresh, otherwise crawls it with fetch.
+// Concurrent requests for the same key share a single crawl, a failed crawl falls back to the stale entry.
+func cachedUserData(ctx context.Context, key string, fetch func(ctx context.Context) ([]byte, error)) (cache.Entry, string, error) {
+	entry, cached := store.Get(key)
+	if cached && entry.Fresh(profileCacheTTL) {
+		return entry, "hit", nil
+	}
+
+	b, _, err := profileGroup.Do(ctx, key, fetch)
+	if err != nil && cached && canServeStale(err) {
+		fmt.Println("ERR", key, "serving stale entry:", err.Error())
+		return entry, "stale", nil
+	}
+	if err != nil {
+		return cache.Entry{}, "", err
+	}
+	return cache.Entry{Value: b, StoredAt: time.Now()}, "miss", nil
+}
+
+func handleGithubProfile(w http.ResponseWriter, r *http.Request) {
+	username := pathParam(r, "login")
+
+	opts, err := fetchOptions(r.URL.Query())
+	if err != nil {
+		writeParamError(w, err)
+		return
+	}
+
+	key := profileCacheKey(username, opts)
+	entry, cacheStatus, err := cachedUserData(r.Context(), key, func(ctx context.Context) ([]byte, error) {
+		return fetchProfile(ctx, username, opts)
+	})
+	if err != nil {
+		fmt.Println("ERR handleGitHubProfile:", err.Error())
+		writeError(w, errorFromGithub(err))
+		return
+	}
+
+	writeEntry(w, r, key, entry, cacheStatus)
+}
+
+// cachedJob returns the cached payload of a background refreshed endpoint.
+// Stale data is returned while a refresh is triggered, missing data is answered with 503 and ok is false.
+func cachedJob(w http.ResponseWriter, jobType string) (entry cache.Entry, cacheStatus string, ok bool) {
+	job := refreshers[jobType]
+	entry, ok = store.Get(jobType)
+	if !ok || len(entry.Value) == 0 {
+		job.kick()
+		writeNotReady(w, job)
+		return entry, "", false
+	}
+
+	cacheStatus = "hit"
+	if !entry.Fresh(job.ttl) {
+		// Stale while revalidate
+		job.kick()
+		cacheStatus = "stale"
+	}
+	return entry, cacheStatus, true
+}
+
+func writeNotReady(w http.ResponseWriter, job *refresher) {
+	writeError(w, &apiError{
+		Status:     http.StatusServiceUnavailable,
+		Code:       codeDataNotReady,
+		Message:    "Data is not available yet, please retry later",
+		RetryAfter: job.retryAfter(),
+	})
+}
+
+// writeCached serves the cached payload of a background refreshed endpoint
+func writeCached(w http.ResponseWriter, r *http.Request, jobType string) {
+	entry, cacheStatus, ok := cachedJob(w, jobType)
+	if !ok {
+		return
+	}
+	writeEntry(w, r, jobType, entry, cacheStatus)
+}
+
+// writeCacheHeaders reports how old the served entry is
+func writeCacheHeaders(w http.ResponseWriter, entry cache.Entry, cacheStatus string) {
+	w.Header().Set("X-Gogithub-Cache", cacheStatus)
+	w.Header().Set("Age", strconv.Itoa(int(entry.Age().Seconds())))
+	w.Header().Set("Last-Modified", entry.StoredAt.UTC().Format(http.TimeFormat))
 }
 
 func handleGithubSummary(w http.ResponseWriter, r *http.Request) {
-	// Asynchronously check cache and update it when it's necessary
-	cacheJobs <- cacheTypeSummary
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-Gogithub-Cache", "true")
-	w.Write(cacheSummary)
-	return
+	writeCached(w, r, cacheTypeSummary)
 }
 
+// handleTopStars serves the top stars crawl ranked by the score options,
+// e.g. ?score_by=stars_per_repo&min_repos=5. Only the default ranking is cached until the next crawl.
 func handleTopStars(w http.ResponseWriter, r *http.Request) {
-	// Asynchronously check cache and update it when it's necessary
-	cacheJobs <- cacheTypeTopStar
+	opts, err := scoreOptions(r.URL.Query())
+	if err != nil {
+		writeParamError(w, err)
+		return
+	}
+	crawlEntry, cacheStatus, ok := cachedJob(w, cacheTypeTopStar)
+	if !ok {
+		return
+	}
+
+	isDefault := opts == defaultScore
+	if isDefault {
+		if entry, ok := store.Get(topStarsRankedKey); ok && !entry.StoredAt.Before(crawlEntry.StoredAt) {
+			// Served as old as the crawl, like the ranking computed from it
+			entry.StoredAt = crawlEntry.StoredAt
+			writeEntry(w, r, topStarsRankedKey, entry, cacheStatus)
+			return
+		}
+	}
+
+	var crawl topStarsCrawl
+	if err := json.Unmarshal(crawlEntry.Value, &crawl); err != nil || len(crawl.Devs) == 0 {
+		// Entry of an older version, crawl again
+		fmt.Println("ERR handleTopStars: unreadable crawl, refreshing")
+		store.Delete(cacheTypeTopStar)
+		job := refreshers[cacheTypeTopStar]
+		job.kick()
+		writeNotReady(w, job)
+		return
+	}
+	b, err := json.Marshal(model.ResponsePayload{
+		Data: rankTopStars(crawl, opts),
+	})
+	if err != nil {
+		fmt.Println("ERR handleTopStars:", err)
+		writeError(w, &apiError{Status: http.StatusInternalServerError, Code: codeInternal, Message: "Failed to rank top stars"})
+		return
+	}
+	// A ranking only changes with the crawl, it's as old as the crawl
+	entry := cache.Entry{Value: b, StoredAt: crawlEntry.StoredAt}
+	if !isDefault {
+		writeEntry(w, r, "", entry, cacheStatus)
+		return
+	}
+	if err := store.Set(topStarsRankedKey, b); err != nil {
+		fmt.Println("ERR handleTopStars: cache:", err)
+	}
+	writeEntry(w, r, topStarsRankedKey, entry, cacheStatus)
+}
+
+// handleSearch runs an ad-hoc segment search, e.g. /gh/search?location=Jakarta&language=Go&followers=>=50
+func handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	segment := github.Segment{
+		Name:      "search",
+		Location:  query.Get("location"),
+		Language:  query.Get("language"),
+		Followers: query.Get("followers"),
+		Size:      10,
+	}
+	if size := query.Get("size"); size != "" {
+		segment.Size, _ = strconv.Atoi(size)
+	}
+
+	if err := segment.Validate(); err != nil {
+		writeError(w, &apiError{Status: http.StatusBadRequest, Code: codeInvalidRequest, Message: err.Error()})
+		return
+	}
+
+	devs, err := ghClient.FetchSegment(r.Context(), segment)
+	if err != nil {
+		fmt.Println("ERR handleSearch:", err.Error())
+		writeError(w, errorFromGithub(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	b, _ := json.Marshal(model.ResponsePayload{
+		Data: github.Developers(devs),
+	})
+	w.Write(b)
+}
+
+func handleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	b, _ := json.Marshal(model.ResponsePayload{
+		Data: leaderboard,
+	})
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-Gogithub-Cache", "true")
-	w.Write(cacheTopStar)
-	return
+	w.Write(b)
+}
+
+func loadLeaderboard() *github.Leaderboard {
+	board, err := github.LoadLeaderboard(config.LeaderboardFile())
+	if err != nil {
+		log.Fatal("Failed to load leaderboard: ", err)
+	}
+	log.Printf("Using leaderboard %q", board.Name)
+	return board
 }
 
 func main() {
-	http.HandleFunc("/gh/summary", handleGithubSummary)
-	http.HandleFunc("/gh/profile/", handleGithubProfile)
-	http.HandleFunc("/gh/topstars", handleTopStars)
+	// The cache retention depends on the profile TTL
+	profileCacheTTL = config.ProfileCacheTTL()
+	store = newCache()
+	allowPrivateRepos = config.AllowPrivateRepos()
+	score, err := config.ScoreOptions()
+	if err != nil {
+		log.Fatal("Invalid top stars ranking: ", err)
+	}
+	defaultScore = score
+	leaderboard = loadLeaderboard()
+	snapshots = openSnapshots()
+	ghClient = github.NewClient(config.GithubAccessToken())
+	ghClient.URL = config.GithubGraphQLURL()
+	ghClient.Parallelism = config.Parallelism()
 
-	// For testing purpose
-	// http.HandleFunc("/gh/test", handleTest)
+	rt := &router{}
+	rt.handle(http.MethodGet, "/gh/summary", handleGithubSummary)
+	rt.handle(http.MethodGet, "/gh/profile/{login}", handleGithubProfile)
+	rt.handle(http.MethodGet, "/gh/profile/{login}/repos", handleGithubRepos)
+	rt.handle(http.MethodGet, "/gh/profile/{login}/contributions", handleGithubContributions)
+	rt.handle(http.MethodGet, "/gh/topstars", handleTopStars)
+	rt.handle(http.MethodGet, "/gh/leaderboard", handleLeaderboard)
+	rt.handle(http.MethodGet, "/gh/search", handleSearch)
+	rt.handle(http.MethodGet, "/gh/history/profile/{login}", handleProfileHistory)
+	rt.handle(http.MethodGet, "/gh/history/topstars", handleTopStarsHistory)
+	rt.handle(http.MethodGet, "/gh/history/topstars/{login}", handleDevHistory)
 
-	// Initialize chan
-	cacheJobs = make(chan string, 100) // maximum number of jobs that can be queued
-	go checkMemoryCache(cacheJobs)     // start coroutine
+	// For testing purpose
+	// rt.handle(http.MethodGet, "/gh/test", handleTest)
 
-	// Initialize cache
-	processMemoryCache(cacheTypeSummary)
-	processMemoryCache(cacheTypeTopStar)
-	if len(cacheSummary) == 0 || len(cacheTopStar) == 0 {
-		log.Fatal("Failed to initialize in-memory cache!")
-		return
-	}
+	// Refresh cache in the background, the server starts serving right away
+	// with entries persisted by the file cache or 503 until the first crawl is done
+	startRefreshers(config.CacheRefreshInterval())
 
 	addr := config.WebAddress()
 	log.Println("Web server will be listening at " + addr)
-	if err := http.ListenAndServe(addr, nil); err != nil {
+	if err := http.ListenAndServe(addr, rt); err != nil {
 		panic(err)
 	}
 }
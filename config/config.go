@@ -2,8 +2,11 @@ package config
 
 import (
 	"fmt"
+	"gogithub/github"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -22,6 +25,119 @@ func GithubAccessToken() string {
 	return readEnvOrPanic("GH_ACCESS_TOKEN")
 }
 
+// GithubGraphQLURL get GH_GRAPHQL_URL from os env,
+// set it to point the client at GitHub Enterprise
+func GithubGraphQLURL() string {
+	return readEnv("GH_GRAPHQL_URL", "https://api.github.com/graphql")
+}
+
+// Parallelism get GH_PARALLELISM from os env, number of developers crawled at once
+func Parallelism() int {
+	return readEnvInt("GH_PARALLELISM", 4)
+}
+
+// LeaderboardFile get LEADERBOARD_FILE from os env,
+// empty means the built-in Indonesia leaderboard is used
+func LeaderboardFile() string {
+	return readEnv("LEADERBOARD_FILE", "")
+}
+
+// CacheBackend get CACHE_BACKEND from os env, either "memory" or "file"
+func CacheBackend() string {
+	return readEnv("CACHE_BACKEND", "memory")
+}
+
+// CacheDir get CACHE_DIR from os env, used by the file cache backend
+func CacheDir() string {
+	return readEnv("CACHE_DIR", ".cache")
+}
+
+// CacheMaxEntries get CACHE_MAX_ENTRIES from os env, used by the memory cache backend
+func CacheMaxEntries() int {
+	return readEnvInt("CACHE_MAX_ENTRIES", 1000)
+}
+
+// ProfileCacheTTL get PROFILE_CACHE_TTL from os env (e.g. "30m"),
+// how long a cached profile is served before it's crawled again
+func ProfileCacheTTL() time.Duration {
+	return readEnvDuration("PROFILE_CACHE_TTL", time.Hour)
+}
+
+// CacheRefreshInterval get CACHE_REFRESH_INTERVAL from os env (e.g. "10m"),
+// how often the summary and top stars caches are checked for staleness
+func CacheRefreshInterval() time.Duration {
+	return readEnvDuration("CACHE_REFRESH_INTERVAL", 10*time.Minute)
+}
+
+// AllowPrivateRepos get ALLOW_PRIVATE_REPOS from os env, whether web and gRPC clients may ask for
+// the private repositories visible to GH_ACCESS_TOKEN
+func AllowPrivateRepos() bool {
+	return readEnvBool("ALLOW_PRIVATE_REPOS", false)
+}
+
+// TopStarsScoreBy get TOPSTARS_SCORE_BY from os env, the default ranking of /gh/topstars:
+// stars, forks, followers, composite or stars_per_repo
+func TopStarsScoreBy() string {
+	return readEnv("TOPSTARS_SCORE_BY", "stars")
+}
+
+// TopStarsWeights get TOPSTARS_WEIGHTS from os env, default weights of the composite score
+func TopStarsWeights() string {
+	return readEnv("TOPSTARS_WEIGHTS", "stars:1,forks:1,followers:1")
+}
+
+// TopStarsMinStars get TOPSTARS_MIN_STARS from os env, developers with less stars aren't ranked
+func TopStarsMinStars() int {
+	return readEnvCount("TOPSTARS_MIN_STARS", 50)
+}
+
+// TopStarsMinForks get TOPSTARS_MIN_FORKS from os env
+func TopStarsMinForks() int {
+	return readEnvCount("TOPSTARS_MIN_FORKS", 0)
+}
+
+// TopStarsMinFollowers get TOPSTARS_MIN_FOLLOWERS from os env
+func TopStarsMinFollowers() int {
+	return readEnvCount("TOPSTARS_MIN_FOLLOWERS", 0)
+}
+
+// TopStarsMinRepos get TOPSTARS_MIN_REPOS from os env
+func TopStarsMinRepos() int {
+	return readEnvCount("TOPSTARS_MIN_REPOS", 0)
+}
+
+// ScoreOptions reads the default top stars ranking from the TOPSTARS_* variables,
+// an error is returned when the weights or the ranking are invalid
+func ScoreOptions() (github.ScoreOptions, error) {
+	weights, err := github.ParseWeights(TopStarsWeights())
+	if err != nil {
+		return github.ScoreOptions{}, fmt.Errorf("TOPSTARS_WEIGHTS: %w", err)
+	}
+	opts := github.ScoreOptions{
+		By:           TopStarsScoreBy(),
+		MinStars:     TopStarsMinStars(),
+		MinForks:     TopStarsMinForks(),
+		MinFollowers: TopStarsMinFollowers(),
+		MinRepos:     TopStarsMinRepos(),
+		Weights:      weights,
+	}
+	if err := opts.Validate(); err != nil {
+		return github.ScoreOptions{}, err
+	}
+	return opts, nil
+}
+
+// SnapshotDir get SNAPSHOT_DIR from os env, where profile and top stars crawls are recorded
+// for trends, empty disables the history
+func SnapshotDir() string {
+	return readEnv("SNAPSHOT_DIR", ".snapshots")
+}
+
+// SnapshotRetention get SNAPSHOT_RETENTION from os env, how long recorded crawls are kept
+func SnapshotRetention() time.Duration {
+	return readEnvDuration("SNAPSHOT_RETENTION", 90*24*time.Hour)
+}
+
 func WebAddress() string {
 	return readEnv("WEB_ADDRESS", ":8080")
 }
@@ -42,6 +158,39 @@ func readEnvOrPanic(envName string) string {
 	return val
 }
 
+func readEnvInt(envName string, defaultValue int) int {
+	val, err := strconv.Atoi(readEnv(envName, ""))
+	if err != nil || val <= 0 {
+		return defaultValue
+	}
+	return val
+}
+
+// readEnvCount is readEnvInt accepting 0
+func readEnvCount(envName string, defaultValue int) int {
+	val, err := strconv.Atoi(readEnv(envName, ""))
+	if err != nil || val < 0 {
+		return defaultValue
+	}
+	return val
+}
+
+func readEnvBool(envName string, defaultValue bool) bool {
+	val, err := strconv.ParseBool(readEnv(envName, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+func readEnvDuration(envName string, defaultValue time.Duration) time.Duration {
+	val, err := time.ParseDuration(readEnv(envName, ""))
+	if err != nil || val <= 0 {
+		return defaultValue
+	}
+	return val
+}
+
 func readEnv(envName string, defaultValue string) string {
 	if val, ok := os.LookupEnv(envName); ok {
 		return val
@@ -0,0 +1,236 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultGraphQLURL = public GitHub GraphQL endpoint
+const DefaultGraphQLURL = "https://api.github.com/graphql"
+
+const defaultUserAgent = "gogithub"
+
+// TokenSource provides the access token sent with every request
+type TokenSource interface {
+	Token() string
+}
+
+// StaticToken - TokenSource which always returns the same token
+type StaticToken string
+
+// Token implements TokenSource
+func (t StaticToken) Token() string {
+	return string(t)
+}
+
+// TokenFunc - TokenSource backed by a function, e.g. config.GithubAccessToken
+type TokenFunc func() string
+
+// Token implements TokenSource
+func (f TokenFunc) Token() string {
+	return f()
+}
+
+// Default scheduling limits used by NewClient
+const (
+	DefaultMinRemaining  = 50
+	DefaultMaxConcurrent = 8
+	DefaultParallelism   = 4
+)
+
+// Client = GitHub GraphQL client
+//
+// URL can point to GitHub Enterprise (https://<host>/api/graphql)
+// or to a local stand-in server for testing.
+//
+// Requests wait when the GraphQL budget drops to MinRemaining points
+// until it resets, or when GitHub asked to back off via Retry-After.
+// At most MaxConcurrent requests are in flight, 0 means no limit.
+// Both are read once, when the first request is sent.
+//
+// Parallelism bounds the number of developers crawled at once by FetchAllStars.
+//
+// Transient failures are retried according to Retry, nil disables retries.
+// Retries are reported to Logger (when set) and counted in Stats.
+type Client struct {
+	URL           string
+	Tokens        TokenSource
+	HTTPClient    *http.Client
+	UserAgent     string
+	MinRemaining  int
+	MaxConcurrent int
+	Parallelism   int
+	Retry         *RetryPolicy
+	Logger        *log.Logger
+
+	limiterOnce sync.Once
+	limiter     *rateLimiter
+	stats       clientStats
+}
+
+// NewClient creates client for public GitHub using given token
+func NewClient(token string) *Client {
+	return &Client{
+		URL:           DefaultGraphQLURL,
+		Tokens:        StaticToken(token),
+		HTTPClient:    &http.Client{},
+		UserAgent:     defaultUserAgent,
+		MinRemaining:  DefaultMinRemaining,
+		MaxConcurrent: DefaultMaxConcurrent,
+		Parallelism:   DefaultParallelism,
+		Retry:         DefaultRetryPolicy(),
+		Logger:        log.New(os.Stderr, "", log.LstdFlags),
+	}
+}
+
+func (c *Client) parallelism() int {
+	if c.Parallelism > 0 {
+		return c.Parallelism
+	}
+	return DefaultParallelism
+}
+
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.Logger != nil {
+		c.Logger.Printf(format, v...)
+	}
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// FetchGhGql = generic fetch for github gql, the request is aborted once ctx is done
+func (c *Client) FetchGhGql(ctx context.Context, query string, variables map[string]interface{}) (map[string]interface{}, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	policy := c.Retry
+	for attempt := 0; ; attempt++ {
+		data, err := c.send(ctx, body)
+		if err == nil {
+			c.stats.add(1, 0, 0)
+			return data, nil
+		}
+		if attempt+1 >= policy.attempts() || !policy.retryable(err) {
+			c.stats.add(1, 0, 1)
+			return nil, err
+		}
+
+		wait := policy.backoff(attempt)
+		c.stats.add(1, 1, 0)
+		c.logf("github: attempt %d/%d failed, retrying in %s: %v", attempt+1, policy.attempts(), wait.Round(time.Millisecond), err)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			c.stats.add(0, 0, 1)
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// send performs a single GraphQL request
+func (c *Client) send(ctx context.Context, body []byte) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Tokens != nil {
+		req.Header.Set("Authorization", "bearer "+c.Tokens.Token())
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	limiter := c.rateLimiter()
+	if err := limiter.acquire(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient().Do(req)
+	limiter.release()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if rl, ok := rateLimitFromHeader(resp.Header); ok {
+		limiter.update(rl)
+	}
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err := errorFromStatus(resp, errorMessage(raw))
+		if rateLimit, ok := err.(*RateLimitError); ok {
+			limiter.block(rateLimit.Wait())
+		}
+		return nil, err
+	}
+
+	var gqlResp struct {
+		Data   map[string]interface{} `json:"data"`
+		Errors []GraphQLError         `json:"errors"`
+	}
+	err = json.Unmarshal(raw, &gqlResp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rl, ok := rateLimitFromData(gqlResp.Data); ok {
+		limiter.update(rl)
+	}
+
+	if len(gqlResp.Errors) > 0 {
+		err := errorFromGraphQL(gqlResp.Errors, resp.Header)
+		if rateLimit, ok := err.(*RateLimitError); ok {
+			limiter.block(rateLimit.Wait())
+		}
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"data": gqlResp.Data,
+	}, nil
+}
+
+// errorMessage extracts GitHub's `message` from an error body, falling back to the raw body
+func errorMessage(raw []byte) string {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(raw, &body); err == nil && body.Message != "" {
+		return body.Message
+	}
+	msg := strings.TrimSpace(string(raw))
+	if len(msg) > 200 {
+		msg = msg[:200]
+	}
+	return msg
+}
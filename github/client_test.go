@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer answers with the responses in order, the last one is repeated.
+// A status of 0 drops the connection without answering.
+func flakyServer(t *testing.T, statuses ...int) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1)) - 1
+		if n >= len(statuses) {
+			n = len(statuses) - 1
+		}
+		switch status := statuses[n]; status {
+		case 0:
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("hijack: %v", err)
+				return
+			}
+			conn.Close()
+		case http.StatusOK:
+			w.Write([]byte(`{"data":{"viewer":{"login":"octocat"}}}`))
+		default:
+			w.WriteHeader(status)
+			w.Write([]byte(`{"message":"flaky"}`))
+		}
+	}))
+	return srv, &calls
+}
+
+func newTestClient(url string, maxAttempts int, baseDelay time.Duration) *Client {
+	c := NewClient("token")
+	c.URL = url
+	c.Logger = nil
+	c.Retry = DefaultRetryPolicy()
+	c.Retry.MaxAttempts = maxAttempts
+	c.Retry.BaseDelay = baseDelay
+	c.Retry.MaxDelay = baseDelay * 8
+	return c
+}
+
+func TestFetchGhGqlRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		attempts int
+		calls    int32
+		wantErr  bool
+		retries  int64
+	}{
+		{"ok", []int{200}, 4, 1, false, 0},
+		{"5xx then ok", []int{502, 503, 200}, 4, 3, false, 2},
+		{"connection reset then ok", []int{0, 200}, 4, 2, false, 1},
+		{"attempt cap", []int{500}, 3, 3, true, 2},
+		{"retries disabled", []int{502, 200}, 1, 1, true, 0},
+		{"4xx not retried", []int{400, 200}, 4, 1, true, 0},
+		{"unauthorized not retried", []int{401, 200}, 4, 1, true, 0},
+		{"forbidden not retried", []int{403, 200}, 4, 1, true, 0},
+		{"404 not retried", []int{404, 200}, 4, 1, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := flakyServer(t, tt.statuses...)
+			defer srv.Close()
+			c := newTestClient(srv.URL, tt.attempts, time.Millisecond)
+
+			data, err := c.FetchGhGql(context.Background(), "query{viewer{login}}", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && data["data"] == nil {
+				t.Errorf("data = %v, want the viewer", data)
+			}
+			if got := atomic.LoadInt32(calls); got != tt.calls {
+				t.Errorf("%d requests, want %d", got, tt.calls)
+			}
+			if got := c.Stats().Retries; got != tt.retries {
+				t.Errorf("%d retries, want %d", got, tt.retries)
+			}
+		})
+	}
+}
+
+func TestFetchGhGqlErrorTypes(t *testing.T) {
+	srv, _ := flakyServer(t, 401)
+	defer srv.Close()
+	c := newTestClient(srv.URL, 4, time.Millisecond)
+	_, err := c.FetchGhGql(context.Background(), "query{viewer{login}}", nil)
+	var auth *AuthError
+	if !errors.As(err, &auth) {
+		t.Fatalf("err = %v, want *AuthError", err)
+	}
+
+	srv2, _ := flakyServer(t, 503)
+	defer srv2.Close()
+	c = newTestClient(srv2.URL, 2, time.Millisecond)
+	_, err = c.FetchGhGql(context.Background(), "query{viewer{login}}", nil)
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 503 {
+		t.Fatalf("err = %v, want *HTTPError 503", err)
+	}
+}
+
+func TestFetchGhGqlCancelDuringBackoff(t *testing.T) {
+	srv, calls := flakyServer(t, 502)
+	defer srv.Close()
+	// The jittered backoff is up to an hour, the cancellation lands while waiting
+	c := newTestClient(srv.URL, 4, time.Hour)
+	c.Retry.Retryable = func(err error) bool { return true }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := c.FetchGhGql(ctx, "query{viewer{login}}", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("returned after %s, want right after the cancellation", elapsed)
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("%d requests, want 1", got)
+	}
+}
@@ -0,0 +1,261 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ContributionOptions = date range of the contribution statistics,
+// zero values let GitHub pick the last year
+type ContributionOptions struct {
+	From time.Time
+	To   time.Time
+}
+
+// Validate checks the range is ordered and spans at most a year, the longest range GitHub
+// accepts for a contributions collection: to can't be later than the same date a year after from
+func (o ContributionOptions) Validate() error {
+	if o.From.IsZero() || o.To.IsZero() {
+		return nil
+	}
+	if !o.From.Before(o.To) {
+		return fmt.Errorf("from must be before to")
+	}
+	if o.To.After(o.From.AddDate(1, 0, 0)) {
+		return fmt.Errorf("from and to must be at most one year apart")
+	}
+	return nil
+}
+
+// ParseContributionRange reads a range given as YYYY-MM-DD dates or RFC 3339 timestamps,
+// a date given as to includes the whole day. Empty values are left to GitHub.
+func ParseContributionRange(from, to string) (ContributionOptions, error) {
+	var opts ContributionOptions
+	var err error
+	if from != "" {
+		if opts.From, err = parseContributionTime(from, false); err != nil {
+			return opts, fmt.Errorf("from: %v", err)
+		}
+	}
+	if to != "" {
+		if opts.To, err = parseContributionTime(to, true); err != nil {
+			return opts, fmt.Errorf("to: %v", err)
+		}
+	}
+	return opts, opts.Validate()
+}
+
+func parseContributionTime(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		if endOfDay {
+			t = t.Add(24*time.Hour - time.Second)
+		}
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return t, fmt.Errorf("%q is neither YYYY-MM-DD nor RFC 3339", value)
+	}
+	return t, nil
+}
+
+// ContributionDay = contributions of a single day of the calendar, Date is YYYY-MM-DD
+type ContributionDay struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// Streak = consecutive days with at least one contribution
+type Streak struct {
+	Days  int    `json:"days"`
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+// RepoContributions = commits and pull requests of the user to a repository
+type RepoContributions struct {
+	Repository   string `json:"repository"`
+	Commits      int    `json:"commits"`
+	PullRequests int    `json:"pull_requests"`
+}
+
+// Contributions = contribution activity of a user over a date range
+type Contributions struct {
+	Login               string    `json:"login"`
+	From                time.Time `json:"from"`
+	To                  time.Time `json:"to"`
+	TotalContributions  int       `json:"total_contributions"`
+	Commits             int       `json:"commits"`
+	PullRequests        int       `json:"pull_requests"`
+	Issues              int       `json:"issues"`
+	Reviews             int       `json:"reviews"`
+	RepositoriesCreated int       `json:"repositories_created"`
+	// Restricted = contributions to private repositories the token can't see
+	Restricted    int                 `json:"restricted"`
+	LongestStreak Streak              `json:"longest_streak"`
+	CurrentStreak Streak              `json:"current_streak"`
+	ContributedTo []RepoContributions `json:"contributed_to"`
+	Calendar      []ContributionDay   `json:"calendar"`
+}
+
+type repoContributionCount struct {
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+	Contributions struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"contributions"`
+}
+
+// contributionsResponse = response of ContributionsQuery
+type contributionsResponse struct {
+	Data struct {
+		User struct {
+			ContributionsCollection struct {
+				StartedAt                            time.Time               `json:"startedAt"`
+				EndedAt                              time.Time               `json:"endedAt"`
+				TotalCommitContributions             int                     `json:"totalCommitContributions"`
+				TotalIssueContributions              int                     `json:"totalIssueContributions"`
+				TotalPullRequestContributions        int                     `json:"totalPullRequestContributions"`
+				TotalPullRequestReviewContributions  int                     `json:"totalPullRequestReviewContributions"`
+				TotalRepositoryContributions         int                     `json:"totalRepositoryContributions"`
+				RestrictedContributionsCount         int                     `json:"restrictedContributionsCount"`
+				CommitContributionsByRepository      []repoContributionCount `json:"commitContributionsByRepository"`
+				PullRequestContributionsByRepository []repoContributionCount `json:"pullRequestContributionsByRepository"`
+				ContributionCalendar                 struct {
+					TotalContributions int `json:"totalContributions"`
+					Weeks              []struct {
+						ContributionDays []struct {
+							Date              string `json:"date"`
+							ContributionCount int    `json:"contributionCount"`
+						} `json:"contributionDays"`
+					} `json:"weeks"`
+				} `json:"contributionCalendar"`
+			} `json:"contributionsCollection"`
+		} `json:"user"`
+	} `json:"data"`
+}
+
+// FetchContributions = fetch contribution statistics of username over the range of opts
+func (c *Client) FetchContributions(ctx context.Context, username string, opts ContributionOptions) (*Contributions, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	vars := map[string]interface{}{
+		"username": username,
+		"from":     nil,
+		"to":       nil,
+	}
+	if !opts.From.IsZero() {
+		vars["from"] = opts.From.UTC().Format(time.RFC3339)
+	}
+	if !opts.To.IsZero() {
+		vars["to"] = opts.To.UTC().Format(time.RFC3339)
+	}
+
+	data, err := c.FetchGhGql(ctx, ContributionsQuery, vars)
+	if err != nil {
+		if nf, ok := err.(*NotFoundError); ok {
+			nf.Login = username
+		}
+		return nil, err
+	}
+	b, _ := json.Marshal(data)
+	var resp contributionsResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+
+	coll := resp.Data.User.ContributionsCollection
+	contrib := &Contributions{
+		Login:               username,
+		From:                coll.StartedAt,
+		To:                  coll.EndedAt,
+		TotalContributions:  coll.ContributionCalendar.TotalContributions,
+		Commits:             coll.TotalCommitContributions,
+		PullRequests:        coll.TotalPullRequestContributions,
+		Issues:              coll.TotalIssueContributions,
+		Reviews:             coll.TotalPullRequestReviewContributions,
+		RepositoriesCreated: coll.TotalRepositoryContributions,
+		Restricted:          coll.RestrictedContributionsCount,
+		Calendar:            []ContributionDay{},
+	}
+	for _, week := range coll.ContributionCalendar.Weeks {
+		for _, day := range week.ContributionDays {
+			contrib.Calendar = append(contrib.Calendar, ContributionDay{Date: day.Date, Count: day.ContributionCount})
+		}
+	}
+	contrib.LongestStreak, contrib.CurrentStreak = streaks(contrib.Calendar)
+	contrib.ContributedTo = mergeRepoContributions(coll.CommitContributionsByRepository, coll.PullRequestContributionsByRepository)
+	return contrib, nil
+}
+
+// streaks returns the longest streak of the calendar and the one running at its end.
+// The last day may still be going on, so a streak ending the day before is current too.
+func streaks(days []ContributionDay) (longest Streak, current Streak) {
+	var run Streak
+	for _, day := range days {
+		if day.Count == 0 {
+			run = Streak{}
+			continue
+		}
+		if run.Days == 0 {
+			run.Start = day.Date
+		}
+		run.Days++
+		run.End = day.Date
+		if run.Days > longest.Days {
+			longest = run
+		}
+	}
+
+	n := len(days)
+	switch {
+	case n == 0:
+	case days[n-1].Count > 0:
+		current = run
+	case n > 1 && days[n-2].Count > 0:
+		// run was reset by the last day, count the streak ending the day before
+		for i := n - 2; i >= 0 && days[i].Count > 0; i-- {
+			current.Days++
+			current.Start = days[i].Date
+		}
+		current.End = days[n-2].Date
+	}
+	return longest, current
+}
+
+// mergeRepoContributions joins commit and pull request counts per repository, most active first
+func mergeRepoContributions(commits, pullRequests []repoContributionCount) []RepoContributions {
+	byRepo := make(map[string]*RepoContributions)
+	list := []RepoContributions{}
+	get := func(name string) *RepoContributions {
+		if rc, ok := byRepo[name]; ok {
+			return rc
+		}
+		rc := &RepoContributions{Repository: name}
+		byRepo[name] = rc
+		return rc
+	}
+	for _, c := range commits {
+		get(c.Repository.NameWithOwner).Commits += c.Contributions.TotalCount
+	}
+	for _, pr := range pullRequests {
+		get(pr.Repository.NameWithOwner).PullRequests += pr.Contributions.TotalCount
+	}
+	for _, rc := range byRepo {
+		list = append(list, *rc)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a := list[i].Commits + list[i].PullRequests
+		b := list[j].Commits + list[j].PullRequests
+		if a != b {
+			return a > b
+		}
+		return list[i].Repository < list[j].Repository
+	})
+	return list
+}
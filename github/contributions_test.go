@@ -0,0 +1,83 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseContributionRange(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	tests := []struct {
+		from, to string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  bool
+	}{
+		{"", "", time.Time{}, time.Time{}, false},
+		{"2023-01-01", "", day("2023-01-01"), time.Time{}, false},
+		{"2023-01-01", "2023-12-31", day("2023-01-01"), day("2024-01-01").Add(-time.Second), false},
+		{"2023-01-01T00:00:00Z", "2024-01-01T00:00:00Z", day("2023-01-01"), day("2024-01-01"), false},
+		{"2024-02-29", "2025-02-28", day("2024-02-29"), day("2025-03-01").Add(-time.Second), false},
+		// to includes the whole day, a year and a day
+		{"2023-01-01", "2024-01-01", time.Time{}, time.Time{}, true},
+		{"2023-01-01T00:00:00Z", "2024-01-01T00:00:01Z", time.Time{}, time.Time{}, true},
+		{"2024-01-01", "2023-01-01", time.Time{}, time.Time{}, true},
+		{"2023-01-01T00:00:00Z", "2023-01-01T00:00:00Z", time.Time{}, time.Time{}, true},
+		{"01/01/2023", "", time.Time{}, time.Time{}, true},
+		{"", "yesterday", time.Time{}, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		opts, err := ParseContributionRange(tt.from, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseContributionRange(%q, %q) err = %v, want error %v", tt.from, tt.to, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if !opts.From.Equal(tt.wantFrom) || !opts.To.Equal(tt.wantTo) {
+			t.Errorf("ParseContributionRange(%q, %q) = %s - %s, want %s - %s", tt.from, tt.to,
+				opts.From, opts.To, tt.wantFrom, tt.wantTo)
+		}
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	calendar := func(counts ...int) []ContributionDay {
+		days := []ContributionDay{}
+		start, _ := time.Parse("2006-01-02", "2024-01-01")
+		for i, n := range counts {
+			days = append(days, ContributionDay{Date: start.AddDate(0, 0, i).Format("2006-01-02"), Count: n})
+		}
+		return days
+	}
+	tests := []struct {
+		name    string
+		days    []ContributionDay
+		longest Streak
+		current Streak
+	}{
+		{"empty", calendar(), Streak{}, Streak{}},
+		{"no contributions", calendar(0, 0, 0), Streak{}, Streak{}},
+		{"single day", calendar(3), Streak{1, "2024-01-01", "2024-01-01"}, Streak{1, "2024-01-01", "2024-01-01"}},
+		{"running", calendar(1, 0, 2, 1, 4),
+			Streak{3, "2024-01-03", "2024-01-05"}, Streak{3, "2024-01-03", "2024-01-05"}},
+		{"ended yesterday is current", calendar(1, 1, 0, 2, 2, 0),
+			Streak{2, "2024-01-01", "2024-01-02"}, Streak{2, "2024-01-04", "2024-01-05"}},
+		{"broken two days ago", calendar(1, 1, 1, 0, 0),
+			Streak{3, "2024-01-01", "2024-01-03"}, Streak{}},
+		{"longest keeps the first of equal runs", calendar(1, 1, 0, 1, 1),
+			Streak{2, "2024-01-01", "2024-01-02"}, Streak{2, "2024-01-04", "2024-01-05"}},
+		{"only yesterday", calendar(0, 5, 0),
+			Streak{1, "2024-01-02", "2024-01-02"}, Streak{1, "2024-01-02", "2024-01-02"}},
+	}
+	for _, tt := range tests {
+		longest, current := streaks(tt.days)
+		if longest != tt.longest || current != tt.current {
+			t.Errorf("%s: streaks = %+v, %+v, want %+v, %+v", tt.name, longest, current, tt.longest, tt.current)
+		}
+	}
+}
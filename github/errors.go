@@ -0,0 +1,152 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuthError - token is missing, invalid or lacks the required scopes
+type AuthError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("github: unauthorized (%d): %s", e.StatusCode, e.Message)
+}
+
+// RateLimitError - primary or secondary rate limit has been hit,
+// ResetAt is zero when GitHub didn't tell when the budget resets
+type RateLimitError struct {
+	ResetAt    time.Time
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e *RateLimitError) Error() string {
+	if e.ResetAt.IsZero() {
+		return fmt.Sprintf("github: rate limited: %s", e.Message)
+	}
+	return fmt.Sprintf("github: rate limited until %s: %s", e.ResetAt.Format(time.RFC3339), e.Message)
+}
+
+// Wait returns how long the caller should back off before retrying
+func (e *RateLimitError) Wait() time.Duration {
+	if e.RetryAfter > 0 {
+		return e.RetryAfter
+	}
+	if !e.ResetAt.IsZero() {
+		return time.Until(e.ResetAt)
+	}
+	return 0
+}
+
+// NotFoundError - requested login doesn't exist
+type NotFoundError struct {
+	Login   string
+	Message string
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Login != "" {
+		return fmt.Sprintf("github: %q not found", e.Login)
+	}
+	return "github: not found: " + e.Message
+}
+
+// GraphQLLocation - position in the query the error refers to
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLError - single entry of the GraphQL `errors` array
+type GraphQLError struct {
+	Type      string            `json:"type"`
+	Message   string            `json:"message"`
+	Path      []interface{}     `json:"path"`
+	Locations []GraphQLLocation `json:"locations"`
+}
+
+func (e *GraphQLError) Error() string {
+	if len(e.Path) == 0 {
+		return "github: graphql: " + e.Message
+	}
+	path := make([]string, len(e.Path))
+	for i, p := range e.Path {
+		path[i] = fmt.Sprint(p)
+	}
+	return fmt.Sprintf("github: graphql: %s (path: %s)", e.Message, strings.Join(path, "."))
+}
+
+// HTTPError - unexpected HTTP status from GitHub, e.g. 502 during an outage
+type HTTPError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("github: unexpected status %d: %s", e.StatusCode, e.Message)
+}
+
+// errorFromStatus converts a non-2xx response into one of the typed errors
+func errorFromStatus(resp *http.Response, message string) error {
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return &AuthError{StatusCode: resp.StatusCode, Message: message}
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && isRateLimited(resp):
+		return &RateLimitError{
+			ResetAt:    parseRateLimitReset(resp.Header),
+			RetryAfter: parseRetryAfter(resp.Header),
+			Message:    message,
+		}
+	case resp.StatusCode == http.StatusForbidden:
+		return &AuthError{StatusCode: resp.StatusCode, Message: message}
+	}
+	return &HTTPError{StatusCode: resp.StatusCode, Message: message}
+}
+
+// errorFromGraphQL picks the most relevant typed error out of the `errors` array
+func errorFromGraphQL(errs []GraphQLError, header http.Header) error {
+	for i := range errs {
+		e := errs[i]
+		switch e.Type {
+		case "NOT_FOUND":
+			return &NotFoundError{Message: e.Message}
+		case "RATE_LIMITED":
+			return &RateLimitError{
+				ResetAt:    parseRateLimitReset(header),
+				RetryAfter: parseRetryAfter(header),
+				Message:    e.Message,
+			}
+		}
+	}
+	return &errs[0]
+}
+
+func isRateLimited(resp *http.Response) bool {
+	return resp.Header.Get("Retry-After") != "" || resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
+
+func parseRateLimitReset(header http.Header) time.Time {
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(reset, 0)
+}
+
+func parseRetryAfter(header http.Header) time.Duration {
+	secs, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
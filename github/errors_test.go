@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// scriptedResponse = single answer of a scriptedServer
+type scriptedResponse struct {
+	status int
+	header map[string]string
+	body   string
+}
+
+// scriptedServer answers with the responses in order, the last one is repeated
+func scriptedServer(responses ...scriptedResponse) *httptest.Server {
+	var calls int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1)) - 1
+		if n >= len(responses) {
+			n = len(responses) - 1
+		}
+		res := responses[n]
+		for k, v := range res.header {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(res.status)
+		w.Write([]byte(res.body))
+	}))
+}
+
+func TestFetchGhGqlTypedErrors(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+	resetHeader := strconv.FormatInt(reset.Unix(), 10)
+
+	tests := []struct {
+		name  string
+		res   scriptedResponse
+		check func(t *testing.T, err error)
+	}{
+		{
+			"graphql NOT_FOUND",
+			scriptedResponse{200, nil, `{"data":{"user":null},"errors":[{"type":"NOT_FOUND","message":"Could not resolve to a User with the login of 'ghost'."}]}`},
+			func(t *testing.T, err error) {
+				var nf *NotFoundError
+				if !errors.As(err, &nf) || nf.Message == "" {
+					t.Errorf("err = %#v, want *NotFoundError with the message", err)
+				}
+			},
+		},
+		{
+			"graphql RATE_LIMITED",
+			scriptedResponse{200, map[string]string{"X-RateLimit-Reset": resetHeader}, `{"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded"}]}`},
+			func(t *testing.T, err error) {
+				var rl *RateLimitError
+				if !errors.As(err, &rl) || !rl.ResetAt.Equal(reset) {
+					t.Errorf("err = %#v, want *RateLimitError resetting at %s", err, reset)
+				}
+			},
+		},
+		{
+			"graphql generic error",
+			scriptedResponse{200, nil, `{"errors":[{"type":"INVALID","message":"bad field","path":["user","repositories",0],"locations":[{"line":3,"column":5}]}]}`},
+			func(t *testing.T, err error) {
+				var gqlErr *GraphQLError
+				if !errors.As(err, &gqlErr) {
+					t.Fatalf("err = %#v, want *GraphQLError", err)
+				}
+				if want := []interface{}{"user", "repositories", float64(0)}; !reflect.DeepEqual(gqlErr.Path, want) {
+					t.Errorf("path = %#v, want %#v", gqlErr.Path, want)
+				}
+				if want := []GraphQLLocation{{Line: 3, Column: 5}}; !reflect.DeepEqual(gqlErr.Locations, want) {
+					t.Errorf("locations = %+v, want %+v", gqlErr.Locations, want)
+				}
+				if gqlErr.Error() != "github: graphql: bad field (path: user.repositories.0)" {
+					t.Errorf("message = %q", gqlErr.Error())
+				}
+			},
+		},
+		{
+			"403 out of budget",
+			scriptedResponse{403, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": resetHeader}, `{"message":"API rate limit exceeded"}`},
+			func(t *testing.T, err error) {
+				var rl *RateLimitError
+				if !errors.As(err, &rl) || !rl.ResetAt.Equal(reset) || rl.Message != "API rate limit exceeded" {
+					t.Errorf("err = %#v, want *RateLimitError resetting at %s", err, reset)
+				}
+			},
+		},
+		{
+			"403 secondary rate limit",
+			scriptedResponse{403, map[string]string{"Retry-After": "30"}, `{"message":"secondary rate limit"}`},
+			func(t *testing.T, err error) {
+				var rl *RateLimitError
+				if !errors.As(err, &rl) || rl.RetryAfter != 30*time.Second {
+					t.Errorf("err = %#v, want *RateLimitError retrying after 30s", err)
+				}
+			},
+		},
+		{
+			"plain 403",
+			scriptedResponse{403, nil, `{"message":"Resource not accessible by integration"}`},
+			func(t *testing.T, err error) {
+				var auth *AuthError
+				if !errors.As(err, &auth) || auth.StatusCode != 403 {
+					t.Errorf("err = %#v, want *AuthError 403", err)
+				}
+			},
+		},
+		{
+			"429",
+			scriptedResponse{429, nil, ``},
+			func(t *testing.T, err error) {
+				var rl *RateLimitError
+				if !errors.As(err, &rl) || rl.Message != "Too Many Requests" {
+					t.Errorf("err = %#v, want *RateLimitError", err)
+				}
+			},
+		},
+		{
+			"unexpected status",
+			scriptedResponse{418, nil, `teapot`},
+			func(t *testing.T, err error) {
+				var httpErr *HTTPError
+				if !errors.As(err, &httpErr) || httpErr.StatusCode != 418 || httpErr.Message != "teapot" {
+					t.Errorf("err = %#v, want *HTTPError 418", err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := scriptedServer(tt.res)
+			defer srv.Close()
+			c := newTestClient(srv.URL, 1, time.Millisecond)
+			_, err := c.FetchGhGql(context.Background(), "query{viewer{login}}", nil)
+			if err == nil {
+				t.Fatal("err = nil")
+			}
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestFetchGhGqlTypedErrorAfterRetry(t *testing.T) {
+	srv := scriptedServer(
+		scriptedResponse{502, nil, `{"message":"bad gateway"}`},
+		scriptedResponse{200, nil, `{"errors":[{"type":"NOT_FOUND","message":"no such user"}]}`},
+	)
+	defer srv.Close()
+	c := newTestClient(srv.URL, 3, time.Millisecond)
+
+	_, err := c.FetchGhGql(context.Background(), "query{viewer{login}}", nil)
+	// Callers wrap the error with their own context
+	err = fmt.Errorf("profile: %w", err)
+	var nf *NotFoundError
+	if !errors.As(err, &nf) {
+		t.Fatalf("err = %v, want *NotFoundError once the retry got an answer", err)
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		t.Errorf("the retried 502 leaked into %v", err)
+	}
+	if got := c.Stats().Retries; got != 1 {
+		t.Errorf("%d retries, want 1", got)
+	}
+}
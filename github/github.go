@@ -1,44 +1,103 @@
 package github
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"gogithub/config"
-	"net/http"
-	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
-const ghGqlURL = "https://api.github.com/graphql"
+// Owner types of RepoData
+const (
+	OwnerTypeUser         = "user"
+	OwnerTypeOrganization = "organization"
+)
+
+// ownerTypes maps the GraphQL __typename of a repository owner to its owner type
+var ownerTypes = map[string]string{
+	"User":         OwnerTypeUser,
+	"Organization": OwnerTypeOrganization,
+}
 
 // RepoData = generated summary from raw data
 type RepoData struct {
-	StarCount   int
-	RepoCount   int
-	ForkCount   int
-	AvatarURL   string
+	StarCount int
+	RepoCount int
+	ForkCount int
+	AvatarURL string
+	// OwnerType = OwnerTypeUser or OwnerTypeOrganization
+	OwnerType string
+	// MemberCount = members of an organization, 0 for users
+	MemberCount int
 	LanguageMap map[string]int32
-	TopRepo     *UserRepositoryEdge
+	// Languages = byte-weighted language breakdown, only set with FetchOptions.Languages
+	Languages []LanguageShare
+	// TopRepos = best FetchOptions.TopN repositories ranked by FetchOptions.TopBy
+	TopRepos []Repository
+	// TopRepo = most starred repository
+	//
+	// Deprecated: use TopRepos
+	TopRepo *UserRepositoryEdge
 }
 
 // UserRepositoryEdge = user's single repo
 type UserRepositoryEdge struct {
 	Node struct {
-		Name            string `json:"name"`
-		ForkCount       int    `json:"forkCount"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		URL         string `json:"url"`
+		Owner       struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+		IsFork          bool      `json:"isFork"`
+		IsPrivate       bool      `json:"isPrivate"`
+		IsArchived      bool      `json:"isArchived"`
+		ForkCount       int       `json:"forkCount"`
+		CreatedAt       time.Time `json:"createdAt"`
+		PushedAt        time.Time `json:"pushedAt"`
 		PrimaryLanguage *struct {
 			Name string `json:"name"`
 		} `json:"primaryLanguage"`
 		Stargazers struct {
 			TotalCount int `json:"totalCount"`
 		} `json:"stargazers"`
+		Languages *repoLanguages `json:"languages,omitempty"`
 	} `json:"node"`
 }
 
-// UserRepositoryResponse = response from user gql for repo
+// Repository converts the edge into a listing entry, topics and license aren't fetched by UserQuery
+func (e UserRepositoryEdge) Repository() Repository {
+	repo := Repository{
+		Name:        e.Node.Name,
+		Description: e.Node.Description,
+		URL:         e.Node.URL,
+		Owner:       e.Node.Owner.Login,
+		Fork:        e.Node.IsFork,
+		Private:     e.Node.IsPrivate,
+		Archived:    e.Node.IsArchived,
+		Stars:       e.Node.Stargazers.TotalCount,
+		Forks:       e.Node.ForkCount,
+		Topics:      []string{},
+		CreatedAt:   e.Node.CreatedAt,
+		PushedAt:    e.Node.PushedAt,
+	}
+	if e.Node.PrimaryLanguage != nil {
+		repo.Language = e.Node.PrimaryLanguage.Name
+	}
+	return repo
+}
+
+// UserRepositoryResponse = response from user gql for repo,
+// Owner is nil when the login is neither a user nor an organization
 type UserRepositoryResponse struct {
 	Data struct {
-		User struct {
-			AvatarURL    string `json:"avatarUrl"`
+		Owner *struct {
+			Typename        string `json:"__typename"`
+			AvatarURL       string `json:"avatarUrl"`
+			MembersWithRole struct {
+				TotalCount int `json:"totalCount"`
+			} `json:"membersWithRole"`
 			Repositories struct {
 				TotalCount int `json:"totalCount"`
 				PageInfo   struct {
@@ -47,54 +106,21 @@ type UserRepositoryResponse struct {
 				} `json:"pageInfo"`
 				Edges []UserRepositoryEdge `json:"edges"`
 			} `json:"repositories"`
-		} `json:"user"`
+		} `json:"repositoryOwner"`
 	} `json:"data"`
 }
 
-// FetchGhGql = generic fetch for github gql
-func FetchGhGql(query, variables string) (map[string]interface{}, error) {
-	body, err := json.Marshal(map[string]string{
-		"query":     query,
-		"variables": variables,
-	})
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequest("POST", ghGqlURL, bytes.NewBuffer(body))
-
-	req.Header.Set("Authorization", "bearer "+config.GithubAccessToken())
-	client := &http.Client{}
-	resp, err := client.Do(req)
-
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-
-	var data map[string]interface{}
-	err = json.NewDecoder(resp.Body).Decode(&data)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return data, nil
-}
-
-// FetchTopUserSummary = fetch all top user using GQL
-func FetchTopUserSummary() (map[string]interface{}, error) {
-	return FetchGhGql(SummaryQuery, "")
-}
-
 // FetchRepo = fetch repo by username
-func FetchRepo(username string, after *string) (*UserRepositoryResponse, error) {
-	variables, _ := json.Marshal(map[string]interface{}{
-		"username": username,
-		"after":    after,
-	})
-	data, err := FetchGhGql(UserQuery, string(variables))
+func (c *Client) FetchRepo(ctx context.Context, username string, after *string, opts FetchOptions) (*UserRepositoryResponse, error) {
+	vars := opts.repositoryVariables()
+	vars["username"] = username
+	vars["after"] = after
+	vars["withLanguages"] = opts.Languages
+	data, err := c.FetchGhGql(ctx, UserQuery, vars)
 	if err != nil {
+		if nf, ok := err.(*NotFoundError); ok {
+			nf.Login = username
+		}
 		return nil, err
 	}
 	b, _ := json.Marshal(data)
@@ -103,36 +129,72 @@ func FetchRepo(username string, after *string) (*UserRepositoryResponse, error)
 	if err != nil {
 		return nil, err
 	}
+	// Unlike user(login:), repositoryOwner(login:) resolves unknown logins to null without an error
+	if resp.Data.Owner == nil {
+		return nil, &NotFoundError{Login: username}
+	}
 	return &resp, nil
 }
 
+// moreStarred reports whether a ranks before b as the top repository.
+// Ties go to the first repository by name, not to the page order, like RankRepositories
+func moreStarred(a, b *UserRepositoryEdge) bool {
+	if a.Node.Stargazers.TotalCount != b.Node.Stargazers.TotalCount {
+		return a.Node.Stargazers.TotalCount > b.Node.Stargazers.TotalCount
+	}
+	return strings.ToLower(a.Node.Name) < strings.ToLower(b.Node.Name)
+}
+
 // FetchAllRepos = fetch all repos by username and create their summary
-func FetchAllRepos(username string) (*RepoData, error) {
+func (c *Client) FetchAllRepos(ctx context.Context, username string, opts FetchOptions) (*RepoData, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
 	avatarURL := ""
+	ownerType := ""
+	memberCount := 0
 	starCount := 0
 	repoCount := 0
 	forkCount := 0
 	langMap := make(map[string]int32)
 	var cursor *string
 	var bestRepo *UserRepositoryEdge
+	var repos []Repository
+	archivedCount := 0
+	langTotals := newLanguageTotals()
 
 	for {
-		data, err := FetchRepo(username, cursor)
+		// Stop paginating as soon as the caller is gone
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		data, err := c.FetchRepo(ctx, username, cursor, opts)
 		if err != nil {
 			return nil, err
 		}
 
-		avatarURL = data.Data.User.AvatarURL
-		repoCount = data.Data.User.Repositories.TotalCount
-
-		for i := 0; i < len(data.Data.User.Repositories.Edges); i++ {
-			edge := data.Data.User.Repositories.Edges[i]
+		owner := data.Data.Owner
+		avatarURL = owner.AvatarURL
+		ownerType = ownerTypes[owner.Typename]
+		if ownerType == OwnerTypeOrganization {
+			memberCount = owner.MembersWithRole.TotalCount
+		}
+		repoCount = owner.Repositories.TotalCount
+
+		for i := 0; i < len(owner.Repositories.Edges); i++ {
+			edge := owner.Repositories.Edges[i]
+			// GitHub can't filter out archived repositories of a user
+			if opts.ExcludeArchived && edge.Node.IsArchived {
+				archivedCount++
+				continue
+			}
 			starCount += edge.Node.Stargazers.TotalCount
 			forkCount += edge.Node.ForkCount
+			repos = append(repos, edge.Repository())
+			langTotals.add(edge.Node.Languages)
 
-			if bestRepo == nil {
-				bestRepo = &edge
-			} else if edge.Node.Stargazers.TotalCount > bestRepo.Node.Stargazers.TotalCount {
+			if bestRepo == nil || moreStarred(&edge, bestRepo) {
 				bestRepo = &edge
 			}
 
@@ -143,21 +205,32 @@ func FetchAllRepos(username string) (*RepoData, error) {
 			}
 		}
 
-		if data.Data.User.Repositories.PageInfo.HasNextPage {
-			cursor = &data.Data.User.Repositories.PageInfo.EndCursor
+		if owner.Repositories.PageInfo.HasNextPage {
+			cursor = &owner.Repositories.PageInfo.EndCursor
 		} else {
 			break
 		}
 	}
 
-	return &RepoData{
+	repoData := &RepoData{
 		AvatarURL:   avatarURL,
+		OwnerType:   ownerType,
+		MemberCount: memberCount,
 		StarCount:   starCount,
-		RepoCount:   repoCount,
+		RepoCount:   repoCount - archivedCount,
 		ForkCount:   forkCount,
 		LanguageMap: langMap,
+		TopRepos:    RankRepositories(repos, opts.TopBy, opts.TopN),
 		TopRepo:     bestRepo,
-	}, nil
+	}
+	if opts.Languages {
+		repoData.Languages = langTotals.shares()
+	}
+	if bestRepo != nil {
+		// The raw languages connection is summarized by Languages
+		bestRepo.Node.Languages = nil
+	}
+	return repoData, nil
 }
 
 // SummaryDev - summary dev for star fetch purpose
@@ -165,6 +238,10 @@ type SummaryDev struct {
 	Node struct {
 		Login     string `json:"login"`
 		Name      string `json:"name"`
+		AvatarURL string `json:"avatarUrl"`
+		Bio       string `json:"bio"`
+		Company   string `json:"company"`
+		Location  string `json:"location"`
 		Following struct {
 			TotalCount int `json:"totalCount"`
 		} `json:"following"`
@@ -174,102 +251,164 @@ type SummaryDev struct {
 	} `json:"node"`
 }
 
-// SummaryData - extract cache for fetching star
-type SummaryData struct {
-	Data struct {
-		TopIndonesiaDev struct {
-			Edges []SummaryDev `json:"edges"`
-		} `json:"topIndonesiaDev"`
-	} `json:"data"`
+// FetchSegment - fetch users matching the segment search, following cursors
+// up to the segment limit. With SplitFollowers the follower range is split
+// into smaller searches whenever it matches more users than GitHub returns.
+func (c *Client) FetchSegment(ctx context.Context, segment Segment) ([]SummaryDev, error) {
+	if err := segment.Validate(); err != nil {
+		return nil, err
+	}
+
+	search := &segmentSearch{
+		client:  c,
+		segment: segment,
+		limit:   segment.limit(),
+		seen:    make(map[string]bool),
+	}
+	if segment.SplitFollowers {
+		// Validate already rejected ranges which don't parse
+		r, _ := parseFollowerRange(segment.Followers)
+		if err := search.searchRange(ctx, r); err != nil {
+			return nil, err
+		}
+		return search.devs, nil
+	}
+
+	q, _ := segment.SearchQuery()
+	page, err := c.fetchSearchPage(ctx, q, segment.Size, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := search.collect(ctx, q, page); err != nil {
+		return nil, err
+	}
+	return search.devs, nil
 }
 
 // DevStar - for single dev star data
 type DevStar struct {
 	AvatarURL string      `json:"avatarUrl"`
 	Stars     int         `json:"stars"`
+	Forks     int         `json:"forks"`
+	Repos     int         `json:"repos"`
 	Dev       *SummaryDev `json:"dev"`
+	// Score the developers are ranked by, see ScoreOptions
+	Score float64 `json:"score"`
+	// Rank starts at 1, PreviousRank and StarsDelta compare it to the previous crawl
+	// and are left to the caller keeping the history, PreviousRank is 0 for a new entry
+	Rank         int `json:"rank"`
+	PreviousRank int `json:"previous_rank"`
+	StarsDelta   int `json:"stars_delta"`
 }
 
-// DevChannel - custom dev channel for async
-type DevChannel struct {
-	Dev      *SummaryDev
-	Data     *RepoData
-	Username string
+// DevError - failure while fetching a single developer's repos
+type DevError struct {
+	Login string
+	Err   error
 }
 
-func asyncFetchRepos(ch chan DevChannel, dev SummaryDev) {
-	username := dev.Node.Login
-	devData, err := FetchAllRepos(username)
-	if err != nil {
-		ch <- DevChannel{
-			Username: username,
-		}
-		return
-	}
-	ch <- DevChannel{
-		Dev:      &dev,
-		Data:     devData,
-		Username: username,
-	}
+func (e *DevError) Error() string {
+	return e.Login + ": " + e.Err.Error()
 }
 
-type byDevStar []DevStar
-
-func (s byDevStar) Len() int {
-	return len(s)
+func (e *DevError) Unwrap() error {
+	return e.Err
 }
-func (s byDevStar) Swap(i, j int) {
-	s[i], s[j] = s[j], s[i]
-}
-func (s byDevStar) Less(i, j int) bool {
-	return s[i].Stars > s[j].Stars
+
+// devResult - outcome of a single developer fetch, Data is nil when Err is set
+type devResult struct {
+	Dev  SummaryDev
+	Data *RepoData
+	Err  error
 }
 
-// FetchAllStars - fetch top indonesia dev and their repo to count stars
-func FetchAllStars() ([]DevStar, error) {
-	topData, err := FetchGhGql(TopIndonesiaQuery, "")
+// fetchDevRepos fetches repos of every dev using at most parallelism workers,
+// results are returned in the same order as devs
+func (c *Client) fetchDevRepos(ctx context.Context, devs []SummaryDev, parallelism int) []devResult {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	results := make([]devResult, len(devs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < parallelism && w < len(devs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				data, err := c.FetchAllRepos(ctx, devs[i].Node.Login, FetchOptions{})
+				results[i] = devResult{Dev: devs[i], Data: data, Err: err}
+			}
+		}()
+	}
 
-	if err != nil {
-		return nil, err
+	for i := range devs {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			// Devs never handed to a worker are reported as cancelled
+			for j := i; j < len(devs); j++ {
+				results[j] = devResult{Dev: devs[j], Err: ctx.Err()}
+			}
+			close(jobs)
+			wg.Wait()
+			return results
+		}
 	}
+	close(jobs)
+	wg.Wait()
 
-	topDataBytes, _ := json.Marshal(topData)
+	return results
+}
 
-	var data SummaryData
-	err = json.Unmarshal(topDataBytes, &data)
-	var devStarList []DevStar
-	var devList []SummaryDev
-	devMap := make(map[string]SummaryDev)
+// FetchAllStars - fetch devs of the segment and their repo to count stars,
+// the devs meeting the minimums of opts are ranked by its score
+//
+// Devs are fetched by at most Client.Parallelism workers. A failing dev
+// doesn't fail the whole ranking, it's reported in the returned DevError list.
+func (c *Client) FetchAllStars(ctx context.Context, segment Segment, opts ScoreOptions) ([]DevStar, []DevError, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+	devs, err := c.FetchSegment(ctx, segment)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	devList = append(devList, data.Data.TopIndonesiaDev.Edges...)
-
-	for i := 0; i < len(devList); i++ {
-		dev := devList[i]
-		devMap[dev.Node.Login] = dev
-	}
+	var devStarList []DevStar
+	var devList []SummaryDev
+	seen := make(map[string]struct{})
 
-	ch := make(chan DevChannel)
-	for _, v := range devMap {
-		go asyncFetchRepos(ch, v)
+	for _, dev := range devs {
+		if _, ok := seen[dev.Node.Login]; ok {
+			continue
+		}
+		seen[dev.Node.Login] = struct{}{}
+		devList = append(devList, dev)
 	}
 
-	for range devMap {
-		devStar := DevStar{}
-		devData := <-ch
-		if devData.Data.StarCount < 50 {
+	var devErrors []DevError
+	for _, res := range c.fetchDevRepos(ctx, devList, c.parallelism()) {
+		if res.Err != nil {
+			devErrors = append(devErrors, DevError{Login: res.Dev.Node.Login, Err: res.Err})
 			continue
 		}
-		devStar.Dev = devData.Dev
-		devStar.Stars = devData.Data.StarCount
-		devStar.AvatarURL = devData.Data.AvatarURL
-		devStarList = append(devStarList, devStar)
+		dev := res.Dev
+		devStarList = append(devStarList, DevStar{
+			Dev:       &dev,
+			Stars:     res.Data.StarCount,
+			Forks:     res.Data.ForkCount,
+			Repos:     res.Data.RepoCount,
+			AvatarURL: res.Data.AvatarURL,
+		})
 	}
 
-	sort.Sort(byDevStar(devStarList))
+	// Cancellation loses the whole ranking, not only a few devs
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
 
-	return devStarList, nil
+	return RankDevStars(devStarList, opts), devErrors, nil
 }
@@ -2,26 +2,390 @@ package github
 
 import (
 	"context"
+	"errors"
+	"gogithub/cache"
 	pb "gogithub/protos"
 	"log"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // GrpcServer is github grpc server
-type GrpcServer struct{}
+type GrpcServer struct {
+	Client      *Client
+	Leaderboard *Leaderboard
+	// AllowPrivateRepos = whether clients may ask for the private repositories visible to the token
+	AllowPrivateRepos bool
+	// DefaultScore = ranking of FetchTopStars for the request fields left unset, it must have been validated
+	DefaultScore ScoreOptions
+	// TopStarsTTL = how long a top stars crawl is reused by FetchTopStars, zero crawls on every call
+	TopStarsTTL time.Duration
+
+	topStarsGroup cache.Group
+	topStarsMu    sync.Mutex
+	topStars      []DevStar
+	topStarsAt    time.Time
+}
 
 // FetchByUsername = implement from proto
 func (s *GrpcServer) FetchByUsername(ctx context.Context, in *pb.GithubRequest) (*pb.GithubResponse, error) {
 	log.Printf("[GithubGrpcServer] Received Username: %v", in.Username)
-	data, err := FetchAllRepos(in.Username)
+	opts := FetchOptions{
+		TopN:            int(in.TopN),
+		TopBy:           in.TopBy,
+		Languages:       in.Languages,
+		IncludeForks:    in.IncludeForks,
+		ExcludeArchived: in.ExcludeArchived,
+		IncludePrivate:  in.IncludePrivate,
+		Affiliations:    in.Affiliations,
+	}
+	if err := s.checkFetchOptions(&opts); err != nil {
+		return nil, err
+	}
+	data, err := s.Client.FetchAllRepos(ctx, in.Username, opts)
 	if err != nil {
-		log.Fatalf("failed to fetch github: %v", err)
+		log.Printf("[GithubGrpcServer] failed to fetch github: %v", err)
+		return nil, grpcError(err)
 	}
 
 	return &pb.GithubResponse{
-		Username:  in.Username,
-		Starcount: int32(data.StarCount),
-		Repocount: int32(data.RepoCount),
-		Forkcount: int32(data.ForkCount),
-		Langmap:   data.LanguageMap,
+		Username:    in.Username,
+		Starcount:   int32(data.StarCount),
+		Repocount:   int32(data.RepoCount),
+		Forkcount:   int32(data.ForkCount),
+		Langmap:     data.LanguageMap,
+		TopRepos:    pbRepositories(data.TopRepos),
+		Languages:   pbLanguages(data.Languages),
+		OwnerType:   data.OwnerType,
+		MemberCount: int32(data.MemberCount),
+	}, nil
+}
+
+// checkFetchOptions validates opts, private repositories are only served when the server allows them
+func (s *GrpcServer) checkFetchOptions(opts *FetchOptions) error {
+	if err := opts.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if opts.IncludePrivate && !s.AllowPrivateRepos {
+		return status.Error(codes.PermissionDenied, "private repositories are not available on this server")
+	}
+	return nil
+}
+
+// SearchUsers = implement from proto, search segment is validated before it's sent to GitHub
+func (s *GrpcServer) SearchUsers(ctx context.Context, in *pb.SearchRequest) (*pb.SearchResponse, error) {
+	segment := Segment{
+		Name:      "search",
+		Location:  in.Location,
+		Language:  in.Language,
+		Followers: in.Followers,
+		Size:      int(in.Size),
+	}
+	if segment.Size == 0 {
+		segment.Size = topSummaryFirst
+	}
+	if err := segment.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	devs, err := s.Client.FetchSegment(ctx, segment)
+	if err != nil {
+		log.Printf("[GithubGrpcServer] failed to search users: %v", err)
+		return nil, grpcError(err)
+	}
+
+	return &pb.SearchResponse{
+		Developers: pbDevelopers(Developers(devs)),
+	}, nil
+}
+
+// FetchSummary = implement from proto, top developers of every leaderboard segment
+func (s *GrpcServer) FetchSummary(ctx context.Context, in *pb.SummaryRequest) (*pb.SummaryResponse, error) {
+	summary, err := s.Client.FetchTopUserSummary(ctx, s.leaderboard())
+	if err != nil {
+		log.Printf("[GithubGrpcServer] failed to fetch summary: %v", err)
+		return nil, grpcError(err)
+	}
+
+	res := &pb.SummaryResponse{
+		Leaderboard: summary.Leaderboard,
+	}
+	for _, seg := range summary.Segments {
+		res.Segments = append(res.Segments, &pb.SegmentSummary{
+			Name:       seg.Name,
+			Location:   seg.Location,
+			Language:   seg.Language,
+			Followers:  seg.Followers,
+			Developers: pbDevelopers(seg.Developers),
+		})
+	}
+	return res, nil
+}
+
+// ListRepositories = implement from proto, every repository of a user sorted, filtered and paged
+func (s *GrpcServer) ListRepositories(ctx context.Context, in *pb.RepositoriesRequest) (*pb.RepositoriesResponse, error) {
+	opts := RepoListOptions{
+		Sort:     in.Sort,
+		Order:    in.Order,
+		Language: in.Language,
+		MinStars: int(in.MinStars),
+		Page:     int(in.Page),
+		PerPage:  int(in.PerPage),
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	fetchOpts := FetchOptions{
+		IncludeForks:    in.IncludeForks,
+		ExcludeArchived: in.ExcludeArchived,
+		IncludePrivate:  in.IncludePrivate,
+		Affiliations:    in.Affiliations,
+	}
+	if err := s.checkFetchOptions(&fetchOpts); err != nil {
+		return nil, err
+	}
+
+	repos, err := s.Client.FetchRepositories(ctx, in.Username, fetchOpts)
+	if err != nil {
+		log.Printf("[GithubGrpcServer] failed to list repositories: %v", err)
+		return nil, grpcError(err)
+	}
+
+	page := ListRepositories(repos, opts)
+	return &pb.RepositoriesResponse{
+		Repositories: pbRepositories(page.Repositories),
+		Total:        int32(page.Total),
+		Page:         int32(page.Page),
+		PerPage:      int32(page.PerPage),
+		Pages:        int32(page.Pages),
 	}, nil
 }
+
+// FetchContributions = implement from proto, contribution statistics of a user, from and to
+// are YYYY-MM-DD dates or RFC 3339 timestamps and default to the last year
+func (s *GrpcServer) FetchContributions(ctx context.Context, in *pb.ContributionsRequest) (*pb.ContributionsResponse, error) {
+	opts, err := ParseContributionRange(in.From, in.To)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	data, err := s.Client.FetchContributions(ctx, in.Username, opts)
+	if err != nil {
+		log.Printf("[GithubGrpcServer] failed to fetch contributions: %v", err)
+		return nil, grpcError(err)
+	}
+
+	res := &pb.ContributionsResponse{
+		Login:               data.Login,
+		From:                data.From.Format(time.RFC3339),
+		To:                  data.To.Format(time.RFC3339),
+		TotalContributions:  int32(data.TotalContributions),
+		Commits:             int32(data.Commits),
+		PullRequests:        int32(data.PullRequests),
+		Issues:              int32(data.Issues),
+		Reviews:             int32(data.Reviews),
+		RepositoriesCreated: int32(data.RepositoriesCreated),
+		Restricted:          int32(data.Restricted),
+		LongestStreak:       pbStreak(data.LongestStreak),
+		CurrentStreak:       pbStreak(data.CurrentStreak),
+	}
+	for _, rc := range data.ContributedTo {
+		res.ContributedTo = append(res.ContributedTo, &pb.RepoContributions{
+			Repository:   rc.Repository,
+			Commits:      int32(rc.Commits),
+			PullRequests: int32(rc.PullRequests),
+		})
+	}
+	for _, day := range data.Calendar {
+		res.Calendar = append(res.Calendar, &pb.ContributionDay{
+			Date:  day.Date,
+			Count: int32(day.Count),
+		})
+	}
+	return res, nil
+}
+
+// FetchTopStars = implement from proto, ranks the developers of the top stars segment of the leaderboard
+// by the requested score, unset fields fall back to DefaultScore
+func (s *GrpcServer) FetchTopStars(ctx context.Context, in *pb.TopStarsRequest) (*pb.TopStarsResponse, error) {
+	opts := s.DefaultScore
+	if in.ScoreBy != "" {
+		opts.By = in.ScoreBy
+	}
+	// Only unset minimums fall back to the defaults, an explicit 0 lifts them
+	for _, m := range []struct {
+		value *wrappers.Int32Value
+		dst   *int
+	}{
+		{in.MinStars, &opts.MinStars},
+		{in.MinForks, &opts.MinForks},
+		{in.MinFollowers, &opts.MinFollowers},
+		{in.MinRepos, &opts.MinRepos},
+	} {
+		if m.value != nil {
+			*m.dst = int(m.value.Value)
+		}
+	}
+	if w := in.Weights; w != nil {
+		opts.Weights = Weights{Stars: w.Stars, Forks: w.Forks, Followers: w.Followers}
+		// Explicit weights are never replaced by the defaults
+		if err := opts.Weights.Validate(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	crawl, err := s.crawlTopStars(ctx)
+	if err != nil {
+		log.Printf("[GithubGrpcServer] failed to fetch top stars: %v", err)
+		return nil, grpcError(err)
+	}
+
+	res := &pb.TopStarsResponse{
+		Leaderboard: s.leaderboard().Name,
+		ScoreBy:     opts.By,
+	}
+	for _, dev := range RankDevStars(crawl, opts) {
+		var developer *pb.Developer
+		if dev.Dev != nil {
+			developer = pbDevelopers([]Developer{dev.Dev.Developer()})[0]
+		}
+		res.Devs = append(res.Devs, &pb.TopStarsDev{
+			Developer: developer,
+			Stars:     int32(dev.Stars),
+			Forks:     int32(dev.Forks),
+			Repos:     int32(dev.Repos),
+			Score:     dev.Score,
+			Rank:      int32(dev.Rank),
+		})
+	}
+	return res, nil
+}
+
+// crawlTopStars returns every developer of the top stars segment, the crawl is reused for TopStarsTTL
+// and concurrent calls share a single one
+func (s *GrpcServer) crawlTopStars(ctx context.Context) ([]DevStar, error) {
+	s.topStarsMu.Lock()
+	crawl, at := s.topStars, s.topStarsAt
+	s.topStarsMu.Unlock()
+	if crawl != nil && time.Since(at) < s.TopStarsTTL {
+		return crawl, nil
+	}
+
+	_, _, err := s.topStarsGroup.Do(ctx, "topstars", func(ctx context.Context) ([]byte, error) {
+		devs, devErrors, err := s.Client.FetchAllStars(ctx, s.leaderboard().TopStars, ScoreOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, devErr := range devErrors {
+			log.Printf("[GithubGrpcServer] top stars: %v", devErr.Error())
+		}
+		s.topStarsMu.Lock()
+		s.topStars, s.topStarsAt = devs, time.Now()
+		s.topStarsMu.Unlock()
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.topStarsMu.Lock()
+	defer s.topStarsMu.Unlock()
+	return s.topStars, nil
+}
+
+func (s *GrpcServer) leaderboard() *Leaderboard {
+	if s.Leaderboard != nil {
+		return s.Leaderboard
+	}
+	return &DefaultLeaderboard
+}
+
+func pbDevelopers(devs []Developer) []*pb.Developer {
+	list := make([]*pb.Developer, 0, len(devs))
+	for _, dev := range devs {
+		list = append(list, &pb.Developer{
+			Login:     dev.Login,
+			Name:      dev.Name,
+			AvatarUrl: dev.AvatarURL,
+			Bio:       dev.Bio,
+			Company:   dev.Company,
+			Location:  dev.Location,
+			Followers: int32(dev.Followers),
+			Following: int32(dev.Following),
+		})
+	}
+	return list
+}
+
+func pbRepositories(repos []Repository) []*pb.Repository {
+	list := make([]*pb.Repository, 0, len(repos))
+	for _, repo := range repos {
+		list = append(list, &pb.Repository{
+			Name:        repo.Name,
+			Description: repo.Description,
+			Url:         repo.URL,
+			Stars:       int32(repo.Stars),
+			Forks:       int32(repo.Forks),
+			Language:    repo.Language,
+			Topics:      repo.Topics,
+			License:     repo.License,
+			CreatedAt:   repo.CreatedAt.Format(time.RFC3339),
+			PushedAt:    repo.PushedAt.Format(time.RFC3339),
+			Owner:       repo.Owner,
+			Fork:        repo.Fork,
+			Private:     repo.Private,
+			Archived:    repo.Archived,
+		})
+	}
+	return list
+}
+
+func pbLanguages(langs []LanguageShare) []*pb.LanguageShare {
+	list := make([]*pb.LanguageShare, 0, len(langs))
+	for _, lang := range langs {
+		list = append(list, &pb.LanguageShare{
+			Name:    lang.Name,
+			Color:   lang.Color,
+			Bytes:   lang.Bytes,
+			Percent: lang.Percent,
+		})
+	}
+	return list
+}
+
+func pbStreak(streak Streak) *pb.Streak {
+	return &pb.Streak{
+		Days:  int32(streak.Days),
+		Start: streak.Start,
+		End:   streak.End,
+	}
+}
+
+// grpcError maps fetch errors to gRPC status codes
+func grpcError(err error) error {
+	var notFound *NotFoundError
+	var auth *AuthError
+	var rateLimit *RateLimitError
+	var gqlErr *GraphQLError
+	switch {
+	case errors.As(err, &notFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &auth):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.As(err, &rateLimit):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.As(err, &gqlErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "gogithub/protos"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGrpcPrivateReposNotAllowed(t *testing.T) {
+	// The check happens before anything is sent to GitHub
+	s := &GrpcServer{Client: NewClient("token")}
+	s.Client.URL = "http://127.0.0.1:0"
+
+	_, err := s.FetchByUsername(context.Background(), &pb.GithubRequest{Username: "octocat", IncludePrivate: true})
+	if got := status.Code(err); got != codes.PermissionDenied {
+		t.Errorf("FetchByUsername: code %v, want PermissionDenied (%v)", got, err)
+	}
+	_, err = s.ListRepositories(context.Background(), &pb.RepositoriesRequest{Username: "octocat", IncludePrivate: true})
+	if got := status.Code(err); got != codes.PermissionDenied {
+		t.Errorf("ListRepositories: code %v, want PermissionDenied (%v)", got, err)
+	}
+	_, err = s.ListRepositories(context.Background(), &pb.RepositoriesRequest{Username: "octocat", Sort: "size", IncludePrivate: true})
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("ListRepositories with a bad sort: code %v, want InvalidArgument (%v)", got, err)
+	}
+}
+
+func TestGrpcFetchTopStars(t *testing.T) {
+	dev := func(login string, stars int) DevStar {
+		d := &SummaryDev{}
+		d.Node.Login = login
+		return DevStar{Stars: stars, Repos: 1, Dev: d}
+	}
+	s := &GrpcServer{
+		Client:       newTestClient("http://127.0.0.1:0", 1, time.Millisecond),
+		DefaultScore: ScoreOptions{MinStars: 50},
+		TopStarsTTL:  time.Hour,
+	}
+	// Nothing is sent to GitHub while the crawl is fresh
+	s.topStars = []DevStar{dev("alice", 80), dev("bob", 60), dev("carol", 10)}
+	s.topStarsAt = time.Now()
+
+	tests := []struct {
+		name string
+		in   *pb.TopStarsRequest
+		want []string
+		code codes.Code
+	}{
+		{"server default", &pb.TopStarsRequest{}, []string{"alice", "bob"}, codes.OK},
+		{"own minimum", &pb.TopStarsRequest{MinStars: &wrappers.Int32Value{Value: 70}}, []string{"alice"}, codes.OK},
+		{"explicit zero minimum", &pb.TopStarsRequest{MinStars: &wrappers.Int32Value{}}, []string{"alice", "bob", "carol"}, codes.OK},
+		{"negative minimum", &pb.TopStarsRequest{MinRepos: &wrappers.Int32Value{Value: -1}}, nil, codes.InvalidArgument},
+		{"all zero weights", &pb.TopStarsRequest{ScoreBy: ScoreComposite, Weights: &pb.ScoreWeights{}}, nil, codes.InvalidArgument},
+		{"unknown score", &pb.TopStarsRequest{ScoreBy: "issues"}, nil, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		res, err := s.FetchTopStars(context.Background(), tt.in)
+		if got := status.Code(err); got != tt.code {
+			t.Errorf("%s: code %v, want %v (%v)", tt.name, got, tt.code, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		var logins []string
+		for _, d := range res.Devs {
+			logins = append(logins, d.Developer.Login)
+		}
+		if len(logins) != len(tt.want) {
+			t.Errorf("%s: ranked %v, want %v", tt.name, logins, tt.want)
+			continue
+		}
+		for i := range logins {
+			if logins[i] != tt.want[i] {
+				t.Errorf("%s: ranked %v, want %v", tt.name, logins, tt.want)
+				break
+			}
+		}
+	}
+
+	// An expired crawl is fetched again
+	s.topStarsAt = time.Now().Add(-2 * time.Hour)
+	if _, err := s.FetchTopStars(context.Background(), &pb.TopStarsRequest{}); err == nil {
+		t.Errorf("expired crawl was served without crawling again")
+	}
+}
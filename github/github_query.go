@@ -1,15 +1,28 @@
 package github
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 const topSummaryFirst = 10
 
-// UserQuery = query used when fetching profile
+// UserQuery = query used when fetching profile of a user or an organization,
+// the byte size of each language is only fetched when $withLanguages is set.
+// Forks, private and organization repositories are selected by FetchOptions, see repositoryVariables.
 var UserQuery = `
-query getUserRepo($username: String!, $after: String) {
-	user(login:$username){
+query getUserRepo($username: String!, $after: String, $withLanguages: Boolean = false,
+	$isFork: Boolean, $privacy: RepositoryPrivacy, $affiliations: [RepositoryAffiliation]) {
+	repositoryOwner(login:$username){
+	  __typename
 	  avatarUrl
-	  repositories(after:$after, first:100, ownerAffiliations:OWNER, isFork:false, privacy:PUBLIC){
+	  ... on Organization {
+		membersWithRole {
+		  totalCount
+		}
+	  }
+	  repositories(after:$after, first:100, ownerAffiliations:$affiliations, isFork:$isFork, privacy:$privacy){
 		totalCount
 		pageInfo{
 		  endCursor
@@ -18,25 +31,90 @@ query getUserRepo($username: String!, $after: String) {
 		edges{
 		  node{
 			name
+			description
+			url
+			owner {
+			  login
+			}
+			isFork
+			isPrivate
+			isArchived
 			forkCount
+			createdAt
+			pushedAt
 			primaryLanguage {
 			  name
 			}
 			stargazers {
 			  totalCount
 			}
+			languages(first:` + strconv.Itoa(maxRepoLanguages) + `, orderBy:{field:SIZE, direction:DESC}) @include(if:$withLanguages) {
+			  edges {
+				size
+				node {
+				  name
+				  color
+				}
+			  }
+			}
 		  }
 		}
 	  }
 	}
+` + rateLimitField + `
 }
 `
 
-func generateSummaryQuery(name, location, language, followers string, first int) string {
-	return fmt.Sprintf(`
-	%s: search(query: "location:%s language:%s followers:%s", type: USER, first: %d) {
-		edges {
-			node {
+// RepositoriesQuery = query used when listing every repository of a user or an organization
+var RepositoriesQuery = `
+query getUserRepositories($username: String!, $after: String,
+	$isFork: Boolean, $privacy: RepositoryPrivacy, $affiliations: [RepositoryAffiliation]) {
+	repositoryOwner(login:$username){
+	  repositories(after:$after, first:100, ownerAffiliations:$affiliations, isFork:$isFork, privacy:$privacy){
+		totalCount
+		pageInfo{
+		  endCursor
+		  hasNextPage
+		}
+		nodes{
+		  name
+		  description
+		  url
+		  owner {
+			login
+		  }
+		  isFork
+		  isPrivate
+		  isArchived
+		  forkCount
+		  createdAt
+		  pushedAt
+		  primaryLanguage {
+			name
+		  }
+		  stargazers {
+			totalCount
+		  }
+		  licenseInfo {
+			spdxId
+			name
+		  }
+		  repositoryTopics(first:20) {
+			nodes {
+			  topic {
+				name
+			  }
+			}
+		  }
+		}
+	  }
+	}
+` + rateLimitField + `
+}
+`
+
+// userFields = fields fetched for every user found by a search
+const userFields = `
 			... on User {
 				name
 				avatarUrl
@@ -51,57 +129,104 @@ func generateSummaryQuery(name, location, language, followers string, first int)
 				totalCount
 				}
 			}
+`
+
+// generateSummaryQuery returns the search of a segment, its search string is
+// read from the `$<name>` variable so user input never ends up in the query
+func generateSummaryQuery(name string, first int) string {
+	return fmt.Sprintf(`
+	%s: search(query: $%s, type: USER, first: %d) {
+		edges {
+			node {
+			%s
 			}
 		}
 	}
-	`, name, location, language, followers, first)
+	`, name, name, first, userFields)
 }
 
-// SummaryQuery = query used when fetch all summary
-var SummaryQuery = fmt.Sprintf(`
-query topSummary {
-	%s
-	%s
-	%s
-	%s
-	%s
-	%s
-	%s
-	%s
-	%s
-	%s
-	%s
-	%s
-	%s
-	%s
+// SearchUsersQuery = single page of a user search, used to paginate a segment
+var SearchUsersQuery = `
+query searchUsers($q: String!, $first: Int!, $after: String) {
+	search(query: $q, type: USER, first: $first, after: $after) {
+		userCount
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
+		edges {
+			node {
+			` + userFields + `
+			}
+		}
+	}
+` + rateLimitField + `
+}
+`
+
+// BuildSummaryQuery = query used when fetch all summary, one search per segment
+func BuildSummaryQuery(segments []Segment) (string, map[string]interface{}, error) {
+	var params []string
+	var searches strings.Builder
+	variables := make(map[string]interface{})
+	for _, seg := range segments {
+		if err := seg.Validate(); err != nil {
+			return "", nil, err
+		}
+		q, _ := seg.SearchQuery()
+		params = append(params, "$"+seg.Name+": String!")
+		variables[seg.Name] = q
+		searches.WriteString(generateSummaryQuery(seg.Name, seg.Size))
+	}
+	return fmt.Sprintf(`
+query topSummary(%s) {
 	%s
 	%s
   }
-`,
-	generateSummaryQuery("topPHPDev", "Indonesia", "PHP", ">=200", topSummaryFirst),
-	generateSummaryQuery("topJsDev", "Indonesia", "JavaScript", ">=200", topSummaryFirst),
-	generateSummaryQuery("topJavaDev", "Indonesia", "Java", ">=200", topSummaryFirst),
-	generateSummaryQuery("topPythonDev", "Indonesia", "Python", ">=150", topSummaryFirst),
-	generateSummaryQuery("topHTMLDev", "Indonesia", "HTML", ">=150", topSummaryFirst),
-	generateSummaryQuery("topGoDev", "Indonesia", "Go", ">=100", topSummaryFirst),
-	generateSummaryQuery("topRubyDev", "Indonesia", "Ruby", ">=100", topSummaryFirst),
-	generateSummaryQuery("topShellDev", "Indonesia", "Shell", ">=100", topSummaryFirst),
-	generateSummaryQuery("topSwiftDev", "Indonesia", "Swift", ">=50", topSummaryFirst),
-
-	generateSummaryQuery("topJakartaDev", "Jakarta", "*", ">=300", topSummaryFirst),
-	generateSummaryQuery("topBandungDev", "Bandung", "*", ">=200", topSummaryFirst),
-	generateSummaryQuery("topYogyakartaDev", "Yogyakarta", "*", ">=100", topSummaryFirst),
-	generateSummaryQuery("topMalangDev", "Malang", "*", ">=100", topSummaryFirst),
-	generateSummaryQuery("topBaliDev", "Bali", "*", ">=100", topSummaryFirst),
-	generateSummaryQuery("topSurabayaDev", "Surabaya", "*", ">=100", topSummaryFirst),
-	generateSummaryQuery("topSemarangDev", "Semarang", "*", ">=100", topSummaryFirst),
-)
+`, strings.Join(params, ", "), rateLimitField, searches.String()), variables, nil
+}
 
-// TopIndonesiaQuery = query for top overall Indonesia, used for star rating
-var TopIndonesiaQuery = fmt.Sprintf(`
-query topIndonesia {
-	%s
-  }
-`,
-	generateSummaryQuery("topIndonesiaDev", "Indonesia", "*", ">=100", 100),
-)
+// ContributionsQuery = contribution activity of a user, $from and $to span at most a year,
+// GitHub defaults to the last year when they're null
+var ContributionsQuery = `
+query getUserContributions($username: String!, $from: DateTime, $to: DateTime) {
+	user(login:$username){
+	  contributionsCollection(from:$from, to:$to){
+		startedAt
+		endedAt
+		totalCommitContributions
+		totalIssueContributions
+		totalPullRequestContributions
+		totalPullRequestReviewContributions
+		totalRepositoryContributions
+		restrictedContributionsCount
+		commitContributionsByRepository(maxRepositories:100){
+		  repository {
+			nameWithOwner
+		  }
+		  contributions {
+			totalCount
+		  }
+		}
+		pullRequestContributionsByRepository(maxRepositories:100){
+		  repository {
+			nameWithOwner
+		  }
+		  contributions {
+			totalCount
+		  }
+		}
+		contributionCalendar{
+		  totalContributions
+		  weeks {
+			contributionDays {
+			  date
+			  contributionCount
+			}
+		  }
+		}
+	  }
+	}
+` + rateLimitField + `
+}
+`
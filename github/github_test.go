@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// crawlServer answers segment searches with the given logins and the repositories of each of them,
+// "ghost" resolves to a null owner and "broken" fails
+type crawlServer struct {
+	logins []string
+	// delay of every repositories request, block makes them wait for the caller to give up
+	delay time.Duration
+	block bool
+
+	inFlight, peak, repoCalls int32
+}
+
+func (s *crawlServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Variables map[string]interface{} `json:"variables"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+
+	if _, ok := req.Variables["q"]; ok {
+		// A null node and a duplicate are in the way
+		edges := []interface{}{map[string]interface{}{"node": nil}}
+		for _, login := range s.logins {
+			edges = append(edges, map[string]interface{}{"node": map[string]interface{}{"login": login}})
+		}
+		edges = append(edges, edges[1])
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"search": map[string]interface{}{
+			"userCount": len(s.logins), "edges": edges,
+		}}})
+		return
+	}
+
+	atomic.AddInt32(&s.repoCalls, 1)
+	n := atomic.AddInt32(&s.inFlight, 1)
+	defer atomic.AddInt32(&s.inFlight, -1)
+	for {
+		p := atomic.LoadInt32(&s.peak)
+		if n <= p || atomic.CompareAndSwapInt32(&s.peak, p, n) {
+			break
+		}
+	}
+	if s.block {
+		<-r.Context().Done()
+		return
+	}
+	time.Sleep(s.delay)
+
+	login, _ := req.Variables["username"].(string)
+	switch login {
+	case "ghost":
+		w.Write([]byte(`{"data":{"repositoryOwner":null}}`))
+		return
+	case "broken":
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	stars := len(login) * 10
+	fmt.Fprintf(w, `{"data":{"repositoryOwner":{"__typename":"User","repositories":{"totalCount":1,"edges":[
+		{"node":{"name":"repo","stargazers":{"totalCount":%d},"forkCount":1}}]}}}}`, stars)
+}
+
+func crawlClient(url string, parallelism int) *Client {
+	c := newTestClient(url, 1, time.Millisecond)
+	c.Parallelism = parallelism
+	// Only the worker pool bounds the crawl
+	c.MaxConcurrent = 0
+	return c
+}
+
+var crawlSegment = Segment{Name: "test", Location: "Jakarta", Size: 100}
+
+func TestFetchAllStarsParallelism(t *testing.T) {
+	var logins []string
+	for i := 0; i < 12; i++ {
+		logins = append(logins, fmt.Sprintf("dev%d", i))
+	}
+	for _, parallelism := range []int{1, 3} {
+		srv := &crawlServer{logins: logins, delay: 20 * time.Millisecond}
+		ts := httptest.NewServer(srv)
+		devs, devErrors, err := crawlClient(ts.URL, parallelism).FetchAllStars(context.Background(), crawlSegment, ScoreOptions{})
+		ts.Close()
+		if err != nil || len(devErrors) > 0 {
+			t.Fatalf("parallelism %d: %v %v", parallelism, err, devErrors)
+		}
+		if len(devs) != len(logins) {
+			t.Errorf("parallelism %d: %d devs, want %d", parallelism, len(devs), len(logins))
+		}
+		if srv.peak > int32(parallelism) {
+			t.Errorf("parallelism %d: %d requests in flight", parallelism, srv.peak)
+		}
+		if parallelism > 1 && srv.peak < 2 {
+			t.Errorf("parallelism %d: requests never overlapped", parallelism)
+		}
+	}
+}
+
+func TestFetchAllStarsDevErrors(t *testing.T) {
+	srv := &crawlServer{logins: []string{"alice", "ghost", "bo", "broken"}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	devs, devErrors, err := crawlClient(ts.URL, 2).FetchAllStars(context.Background(), crawlSegment, ScoreOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ranked []string
+	for _, dev := range devs {
+		ranked = append(ranked, fmt.Sprintf("%s:%d", dev.Dev.Node.Login, dev.Stars))
+	}
+	if fmt.Sprint(ranked) != "[alice:50 bo:20]" {
+		t.Errorf("ranked %v, want alice and bo", ranked)
+	}
+
+	failed := map[string]error{}
+	for _, devErr := range devErrors {
+		failed[devErr.Login] = devErr.Err
+	}
+	var logins []string
+	for login := range failed {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+	if fmt.Sprint(logins) != "[broken ghost]" {
+		t.Fatalf("failed devs %v, want broken and ghost", logins)
+	}
+	var nf *NotFoundError
+	if !errors.As(failed["ghost"], &nf) || nf.Login != "ghost" {
+		t.Errorf("ghost: %v, want *NotFoundError", failed["ghost"])
+	}
+	var httpErr *HTTPError
+	if !errors.As(failed["broken"], &httpErr) {
+		t.Errorf("broken: %v, want *HTTPError", failed["broken"])
+	}
+	// The null search node was skipped, not crawled with an empty login
+	if srv.repoCalls != 4 {
+		t.Errorf("%d repositories requests, want 4", srv.repoCalls)
+	}
+}
+
+func TestFetchAllStarsCancel(t *testing.T) {
+	var logins []string
+	for i := 0; i < 50; i++ {
+		logins = append(logins, fmt.Sprintf("dev%d", i))
+	}
+	srv := &crawlServer{logins: logins, block: true}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	devs, _, err := crawlClient(ts.URL, 4).FetchAllStars(ctx, crawlSegment, ScoreOptions{})
+	if !errors.Is(err, context.Canceled) || devs != nil {
+		t.Fatalf("FetchAllStars = %d devs, %v, want context.Canceled", len(devs), err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("returned %s after the cancellation", elapsed)
+	}
+	// Devs still queued were never requested
+	if calls := atomic.LoadInt32(&srv.repoCalls); calls > 4 {
+		t.Errorf("%d repositories requests, want at most the 4 workers", calls)
+	}
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"math"
+	"sort"
+)
+
+// maxRepoLanguages is the number of languages fetched per repository, ordered by size
+const maxRepoLanguages = 20
+
+// LanguageShare = bytes of code written in a language across the repositories of a user
+type LanguageShare struct {
+	Name    string  `json:"name"`
+	Color   string  `json:"color"`
+	Bytes   int64   `json:"bytes"`
+	Percent float64 `json:"percent"`
+}
+
+// repoLanguages = languages connection of a repository
+type repoLanguages struct {
+	Edges []struct {
+		Size int64 `json:"size"`
+		Node struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"node"`
+	} `json:"edges"`
+}
+
+// languageTotals sums the byte size of each language across repositories
+type languageTotals struct {
+	bytes  map[string]int64
+	colors map[string]string
+}
+
+func newLanguageTotals() *languageTotals {
+	return &languageTotals{
+		bytes:  make(map[string]int64),
+		colors: make(map[string]string),
+	}
+}
+
+func (t *languageTotals) add(langs *repoLanguages) {
+	if langs == nil {
+		return
+	}
+	for _, edge := range langs.Edges {
+		t.bytes[edge.Node.Name] += edge.Size
+		t.colors[edge.Node.Name] = edge.Node.Color
+	}
+}
+
+// shares returns the languages by size, largest first, with percentages rounded to 2 decimals
+func (t *languageTotals) shares() []LanguageShare {
+	var total int64
+	for _, size := range t.bytes {
+		total += size
+	}
+	list := make([]LanguageShare, 0, len(t.bytes))
+	for name, size := range t.bytes {
+		share := LanguageShare{
+			Name:  name,
+			Color: t.colors[name],
+			Bytes: size,
+		}
+		if total > 0 {
+			share.Percent = math.Round(float64(size)*10000/float64(total)) / 100
+		}
+		list = append(list, share)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Bytes != list[j].Bytes {
+			return list[i].Bytes > list[j].Bytes
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
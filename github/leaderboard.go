@@ -0,0 +1,138 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+const (
+	maxSegmentSize  = 100
+	maxSegmentLimit = 10000
+)
+
+var segmentNameRe = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// reservedSegmentNames are aliases the queries already use, see rateLimitField
+var reservedSegmentNames = map[string]bool{
+	"rateLimit": true,
+}
+
+// Segment = named user search, e.g. top Go devs in Jakarta with >=100 followers
+//
+// Size is the page size (at most 100). Limit is the number of users collected
+// by following cursors, 0 means a single page. GitHub search stops at 1000 results,
+// SplitFollowers lifts that cap by splitting the follower range into smaller searches.
+// Limit and SplitFollowers only apply to paginated searches (top stars, ad-hoc search).
+type Segment struct {
+	Name           string `json:"name"`
+	Location       string `json:"location"`
+	Language       string `json:"language"`
+	Followers      string `json:"followers"`
+	Size           int    `json:"size"`
+	Limit          int    `json:"limit,omitempty"`
+	SplitFollowers bool   `json:"split_followers,omitempty"`
+}
+
+// Leaderboard = segments shown by the summary plus the population ranked by top stars
+type Leaderboard struct {
+	Name     string    `json:"name"`
+	Summary  []Segment `json:"summary"`
+	TopStars Segment   `json:"topstars"`
+}
+
+// DefaultLeaderboard is used when no leaderboard file is configured
+var DefaultLeaderboard = Leaderboard{
+	Name: "Indonesia",
+	Summary: []Segment{
+		{Name: "topPHPDev", Location: "Indonesia", Language: "PHP", Followers: ">=200", Size: topSummaryFirst},
+		{Name: "topJsDev", Location: "Indonesia", Language: "JavaScript", Followers: ">=200", Size: topSummaryFirst},
+		{Name: "topJavaDev", Location: "Indonesia", Language: "Java", Followers: ">=200", Size: topSummaryFirst},
+		{Name: "topPythonDev", Location: "Indonesia", Language: "Python", Followers: ">=150", Size: topSummaryFirst},
+		{Name: "topHTMLDev", Location: "Indonesia", Language: "HTML", Followers: ">=150", Size: topSummaryFirst},
+		{Name: "topGoDev", Location: "Indonesia", Language: "Go", Followers: ">=100", Size: topSummaryFirst},
+		{Name: "topRubyDev", Location: "Indonesia", Language: "Ruby", Followers: ">=100", Size: topSummaryFirst},
+		{Name: "topShellDev", Location: "Indonesia", Language: "Shell", Followers: ">=100", Size: topSummaryFirst},
+		{Name: "topSwiftDev", Location: "Indonesia", Language: "Swift", Followers: ">=50", Size: topSummaryFirst},
+
+		{Name: "topJakartaDev", Location: "Jakarta", Language: "*", Followers: ">=300", Size: topSummaryFirst},
+		{Name: "topBandungDev", Location: "Bandung", Language: "*", Followers: ">=200", Size: topSummaryFirst},
+		{Name: "topYogyakartaDev", Location: "Yogyakarta", Language: "*", Followers: ">=100", Size: topSummaryFirst},
+		{Name: "topMalangDev", Location: "Malang", Language: "*", Followers: ">=100", Size: topSummaryFirst},
+		{Name: "topBaliDev", Location: "Bali", Language: "*", Followers: ">=100", Size: topSummaryFirst},
+		{Name: "topSurabayaDev", Location: "Surabaya", Language: "*", Followers: ">=100", Size: topSummaryFirst},
+		{Name: "topSemarangDev", Location: "Semarang", Language: "*", Followers: ">=100", Size: topSummaryFirst},
+	},
+	TopStars: Segment{Name: "topIndonesiaDev", Location: "Indonesia", Language: "*", Followers: ">=100", Size: 100},
+}
+
+// LoadLeaderboard reads and validates a leaderboard definition from a JSON file,
+// an empty path returns DefaultLeaderboard
+func LoadLeaderboard(path string) (*Leaderboard, error) {
+	if path == "" {
+		return &DefaultLeaderboard, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var board Leaderboard
+	if err := json.Unmarshal(b, &board); err != nil {
+		return nil, fmt.Errorf("leaderboard %s: %v", path, err)
+	}
+	if err := board.Validate(); err != nil {
+		return nil, fmt.Errorf("leaderboard %s: %v", path, err)
+	}
+	return &board, nil
+}
+
+// Validate checks every segment can be turned into a search query
+func (l *Leaderboard) Validate() error {
+	if len(l.Summary) == 0 {
+		return fmt.Errorf("summary has no segments")
+	}
+	names := make(map[string]bool)
+	for _, seg := range append([]Segment{l.TopStars}, l.Summary...) {
+		if err := seg.Validate(); err != nil {
+			return err
+		}
+		if names[seg.Name] {
+			return fmt.Errorf("duplicate segment name %q", seg.Name)
+		}
+		names[seg.Name] = true
+	}
+	return nil
+}
+
+// Validate checks the segment name is usable as GraphQL alias,
+// its size is in range and its qualifiers are safe to search for
+func (s Segment) Validate() error {
+	if !segmentNameRe.MatchString(s.Name) {
+		return fmt.Errorf("segment name %q must be a valid GraphQL alias", s.Name)
+	}
+	// Names starting with __ are reserved for introspection
+	if reservedSegmentNames[s.Name] || strings.HasPrefix(s.Name, "__") {
+		return fmt.Errorf("segment name %q is reserved", s.Name)
+	}
+	if s.Size < 1 || s.Size > maxSegmentSize {
+		return fmt.Errorf("segment %s: size must be between 1 and %d", s.Name, maxSegmentSize)
+	}
+	if s.Limit < 0 || s.Limit > maxSegmentLimit {
+		return fmt.Errorf("segment %s: limit must be between 0 and %d", s.Name, maxSegmentLimit)
+	}
+	if s.Limit > maxSearchResults && !s.SplitFollowers {
+		return fmt.Errorf("segment %s: limit above %d requires split_followers", s.Name, maxSearchResults)
+	}
+	_, err := s.SearchQuery()
+	return err
+}
+
+// limit returns the number of users a paginated search collects
+func (s Segment) limit() int {
+	if s.Limit > 0 {
+		return s.Limit
+	}
+	return s.Size
+}
@@ -0,0 +1,30 @@
+package github
+
+import "testing"
+
+func TestSegmentValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"topGoDev", false},
+		{"_private", false},
+		{"rateLimit", true},
+		{"__typename", true},
+		{"1st", true},
+		{"top-dev", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		seg := Segment{Name: tt.name, Location: "Indonesia", Language: "Go", Followers: ">=100", Size: 10}
+		if err := seg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestDefaultLeaderboardValid(t *testing.T) {
+	if err := DefaultLeaderboard.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
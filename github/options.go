@@ -0,0 +1,98 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultTopN = 5
+	maxTopN     = 100
+)
+
+// Repository affiliations of the user, see FetchOptions.Affiliations
+const (
+	AffiliationOwner              = "OWNER"
+	AffiliationCollaborator       = "COLLABORATOR"
+	AffiliationOrganizationMember = "ORGANIZATION_MEMBER"
+)
+
+// FetchOptions = what FetchAllRepos fetches and how it summarizes it.
+// Zero values mean: public non-fork repositories owned by the user,
+// top 5 repositories ranked by stars, no language sizes.
+type FetchOptions struct {
+	TopN  int    // number of TopRepos, at most 100
+	TopBy string // stars, forks or pushed (most recent activity first)
+	// Languages fetches the languages of every repository to fill RepoData.Languages,
+	// it costs more rate limit points than the primary language count
+	Languages bool
+
+	IncludeForks    bool
+	ExcludeArchived bool
+	// IncludePrivate adds the private repositories the token can see
+	IncludePrivate bool
+	// Affiliations = OWNER, COLLABORATOR and/or ORGANIZATION_MEMBER, defaults to OWNER
+	Affiliations []string
+}
+
+// Validate checks the options and fills in the defaults
+func (o *FetchOptions) Validate() error {
+	if o.TopN == 0 {
+		o.TopN = defaultTopN
+	}
+	if o.TopN < 0 || o.TopN > maxTopN {
+		return fmt.Errorf("top must be between 1 and %d", maxTopN)
+	}
+	switch o.TopBy {
+	case "":
+		o.TopBy = "stars"
+	case "stars", "forks", "pushed":
+	default:
+		return fmt.Errorf("unknown top_by %q, use stars, forks or pushed", o.TopBy)
+	}
+
+	// Canonical order, the options are part of cache keys
+	seen := make(map[string]bool)
+	for _, value := range o.Affiliations {
+		switch a := strings.ToUpper(strings.TrimSpace(value)); a {
+		case AffiliationOwner, AffiliationCollaborator, AffiliationOrganizationMember:
+			seen[a] = true
+		default:
+			return fmt.Errorf("unknown affiliation %q, use owner, collaborator or organization_member", value)
+		}
+	}
+	o.Affiliations = nil
+	for _, a := range []string{AffiliationOwner, AffiliationCollaborator, AffiliationOrganizationMember} {
+		if seen[a] {
+			o.Affiliations = append(o.Affiliations, a)
+		}
+	}
+	if len(o.Affiliations) == 0 {
+		o.Affiliations = []string{AffiliationOwner}
+	}
+	return nil
+}
+
+// repositoryVariables = connection arguments of UserQuery and RepositoriesQuery,
+// null means no filter. opts must have been validated.
+func (o FetchOptions) repositoryVariables() map[string]interface{} {
+	vars := map[string]interface{}{
+		"affiliations": o.Affiliations,
+		"isFork":       nil,
+		"privacy":      nil,
+	}
+	if !o.IncludeForks {
+		vars["isFork"] = false
+	}
+	if !o.IncludePrivate {
+		vars["privacy"] = "PUBLIC"
+	}
+	return vars
+}
+
+// DefaultSelection reports whether the options select the repositories of the zero FetchOptions,
+// i.e. counts fetched with them are comparable over time. opts must have been validated.
+func (o FetchOptions) DefaultSelection() bool {
+	return !o.IncludeForks && !o.ExcludeArchived && !o.IncludePrivate &&
+		len(o.Affiliations) == 1 && o.Affiliations[0] == AffiliationOwner
+}
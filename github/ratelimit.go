@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimitField is appended to every query so each response reports its budget
+const rateLimitField = `
+	rateLimit {
+		limit
+		cost
+		remaining
+		resetAt
+	}
+`
+
+// RateLimit = GraphQL point budget as last reported by GitHub
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Cost      int       `json:"cost"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"resetAt"`
+}
+
+// rateLimiter keeps track of the budget shared by all requests of a Client
+// and holds requests back when the budget is exhausted or GitHub asked to back off
+type rateLimiter struct {
+	mu           sync.Mutex
+	budget       RateLimit
+	known        bool
+	blockedUntil time.Time
+	minRemaining int
+	slots        chan struct{}
+}
+
+func newRateLimiter(minRemaining, maxConcurrent int) *rateLimiter {
+	l := &rateLimiter{minRemaining: minRemaining}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+	return l
+}
+
+// acquire blocks until a request may be sent, release must be called afterwards
+func (l *rateLimiter) acquire(ctx context.Context) error {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	for {
+		wait := l.delay()
+		if wait <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			l.release()
+			return ctx.Err()
+		}
+	}
+}
+
+func (l *rateLimiter) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// delay returns how long the next request has to wait
+func (l *rateLimiter) delay() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+	if l.known && l.budget.Remaining <= l.minRemaining && now.Before(l.budget.ResetAt) {
+		return l.budget.ResetAt.Sub(now)
+	}
+	return 0
+}
+
+// update records the budget reported by GitHub, older reports never win over newer ones
+func (l *rateLimiter) update(rl RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.known && rl.ResetAt.Equal(l.budget.ResetAt) && rl.Remaining > l.budget.Remaining {
+		return
+	}
+	if rl.Limit == 0 {
+		rl.Limit = l.budget.Limit
+	}
+	l.budget = rl
+	l.known = true
+}
+
+// block holds back every request for d, e.g. after a Retry-After response
+func (l *rateLimiter) block(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := time.Now().Add(d)
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+func (l *rateLimiter) current() (RateLimit, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.budget, l.known
+}
+
+// rateLimitFromHeader reads the X-RateLimit-* headers, ok is false when they're absent
+func rateLimitFromHeader(header http.Header) (RateLimit, bool) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	limit, _ := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	return RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		ResetAt:   parseRateLimitReset(header),
+	}, true
+}
+
+// rateLimitFromData pops the `rateLimit` field out of the response data
+func rateLimitFromData(data map[string]interface{}) (RateLimit, bool) {
+	raw, ok := data["rateLimit"]
+	if !ok {
+		return RateLimit{}, false
+	}
+	delete(data, "rateLimit")
+
+	b, _ := json.Marshal(raw)
+	var rl RateLimit
+	if err := json.Unmarshal(b, &rl); err != nil {
+		return RateLimit{}, false
+	}
+	return rl, true
+}
+
+func (c *Client) rateLimiter() *rateLimiter {
+	c.limiterOnce.Do(func() {
+		c.limiter = newRateLimiter(c.MinRemaining, c.MaxConcurrent)
+	})
+	return c.limiter
+}
+
+// RateLimit returns the GraphQL budget reported by the latest response,
+// ok is false until the first request completes
+func (c *Client) RateLimit() (rl RateLimit, ok bool) {
+	return c.rateLimiter().current()
+}
@@ -0,0 +1,208 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// budgetBody = GraphQL answer reporting the given budget in the rateLimit field
+func budgetBody(remaining int, resetAt time.Time) string {
+	return fmt.Sprintf(`{"data":{"viewer":{"login":"octocat"},"rateLimit":{"limit":5000,"cost":1,"remaining":%d,"resetAt":%q}}}`,
+		remaining, resetAt.UTC().Format(time.RFC3339Nano))
+}
+
+func TestRateLimiterWaitsForReset(t *testing.T) {
+	resetAt := time.Now().Add(300 * time.Millisecond)
+	var times []time.Time
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+		w.Write([]byte(budgetBody(10, resetAt)))
+	}))
+	defer srv.Close()
+	c := newTestClient(srv.URL, 1, time.Millisecond)
+	c.MinRemaining = 10
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.FetchGhGql(context.Background(), "query{viewer{login}}", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if times[1].Before(resetAt) {
+		t.Errorf("second request sent %s before the reset", resetAt.Sub(times[1]))
+	}
+	if rl, ok := c.RateLimit(); !ok || rl.Remaining != 10 || rl.Limit != 5000 {
+		t.Errorf("RateLimit() = %+v, %v", rl, ok)
+	}
+
+	// Once the reset is over the budget is assumed back
+	start := time.Now()
+	if _, err := c.FetchGhGql(context.Background(), "query{viewer{login}}", nil); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("request after the reset waited %s", elapsed)
+	}
+}
+
+func TestRateLimiterWaitIsCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(budgetBody(0, time.Now().Add(time.Hour))))
+	}))
+	defer srv.Close()
+	c := newTestClient(srv.URL, 1, time.Millisecond)
+	c.MaxConcurrent = 1
+
+	if _, err := c.FetchGhGql(context.Background(), "query{viewer{login}}", nil); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.FetchGhGql(ctx, "query{viewer{login}}", nil); err != context.DeadlineExceeded {
+		t.Fatalf("err = %v, want the deadline while waiting for the reset", err)
+	}
+	// The slot taken while waiting was given back
+	select {
+	case c.rateLimiter().slots <- struct{}{}:
+	default:
+		t.Error("the concurrency slot leaked")
+	}
+}
+
+func TestRateLimiterHonoursRetryAfter(t *testing.T) {
+	var calls int32
+	var second time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"You have exceeded a secondary rate limit"}`))
+			return
+		}
+		second = time.Now()
+		w.Write([]byte(`{"data":{"viewer":{"login":"octocat"}}}`))
+	}))
+	defer srv.Close()
+	// The retry itself doesn't wait, only the limiter does
+	c := newTestClient(srv.URL, 2, time.Microsecond)
+	c.Retry.MaxDelay = time.Minute
+
+	start := time.Now()
+	if _, err := c.FetchGhGql(context.Background(), "query{viewer{login}}", nil); err != nil {
+		t.Fatal(err)
+	}
+	if waited := second.Sub(start); waited < time.Second {
+		t.Errorf("retried after %s, want the 1s of Retry-After", waited)
+	}
+}
+
+func TestRateLimiterMaxConcurrent(t *testing.T) {
+	var inFlight, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Write([]byte(`{"data":{"viewer":{"login":"octocat"}}}`))
+	}))
+	defer srv.Close()
+	c := newTestClient(srv.URL, 1, time.Millisecond)
+	c.MaxConcurrent = 3
+
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.FetchGhGql(context.Background(), "query{viewer{login}}", nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if peak > 3 {
+		t.Errorf("%d requests in flight, want at most 3", peak)
+	}
+	if peak < 2 {
+		t.Errorf("%d requests in flight, want them to run concurrently", peak)
+	}
+}
+
+func TestRateLimiterUpdateOrder(t *testing.T) {
+	window := time.Now().Add(time.Hour).Truncate(time.Second)
+	next := window.Add(time.Hour)
+	tests := []struct {
+		name          string
+		header        map[string]string
+		body          string
+		wantRemaining int
+		wantReset     time.Time
+	}{
+		{
+			"body only",
+			nil, budgetBody(4000, window), 4000, window,
+		},
+		{
+			// The body was computed before the header, its higher value is stale
+			"stale body after a newer header",
+			map[string]string{"X-RateLimit-Remaining": "3000", "X-RateLimit-Reset": strconv.FormatInt(window.Unix(), 10)},
+			budgetBody(3500, window), 3000, window,
+		},
+		{
+			"lower body after the header",
+			map[string]string{"X-RateLimit-Remaining": "2500", "X-RateLimit-Reset": strconv.FormatInt(window.Unix(), 10)},
+			budgetBody(2400, window), 2400, window,
+		},
+		{
+			"header only, never goes back up in the same window",
+			map[string]string{"X-RateLimit-Remaining": "2450", "X-RateLimit-Reset": strconv.FormatInt(window.Unix(), 10)},
+			`{"data":{"viewer":{"login":"octocat"}}}`, 2400, window,
+		},
+		{
+			"a new window replaces the budget",
+			map[string]string{"X-RateLimit-Remaining": "4999", "X-RateLimit-Reset": strconv.FormatInt(next.Unix(), 10)},
+			`{"data":{"viewer":{"login":"octocat"}}}`, 4999, next,
+		},
+	}
+
+	var current int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tt := tests[atomic.LoadInt32(&current)]
+		for k, v := range tt.header {
+			w.Header().Set(k, v)
+		}
+		w.Write([]byte(tt.body))
+	}))
+	defer srv.Close()
+	c := newTestClient(srv.URL, 1, time.Millisecond)
+	c.MinRemaining = 0
+
+	for i, tt := range tests {
+		atomic.StoreInt32(&current, int32(i))
+		data, err := c.FetchGhGql(context.Background(), "query{viewer{login}}", nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if _, ok := data["data"].(map[string]interface{})["rateLimit"]; ok {
+			t.Errorf("%s: rateLimit left in the data", tt.name)
+		}
+		rl, _ := c.RateLimit()
+		if rl.Remaining != tt.wantRemaining || !rl.ResetAt.Equal(tt.wantReset) {
+			t.Errorf("%s: budget %d until %s, want %d until %s", tt.name, rl.Remaining, rl.ResetAt, tt.wantRemaining, tt.wantReset)
+		}
+	}
+}
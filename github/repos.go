@@ -0,0 +1,308 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultReposPerPage = 30
+	maxReposPerPage     = 100
+)
+
+// Repository = single repository of a user as returned by repository listings
+type Repository struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	URL         string    `json:"url"`
+	Owner       string    `json:"owner"`
+	Fork        bool      `json:"fork"`
+	Private     bool      `json:"private"`
+	Archived    bool      `json:"archived"`
+	Stars       int       `json:"stars"`
+	Forks       int       `json:"forks"`
+	Language    string    `json:"language"`
+	Topics      []string  `json:"topics"`
+	License     string    `json:"license"`
+	CreatedAt   time.Time `json:"created_at"`
+	PushedAt    time.Time `json:"pushed_at"`
+}
+
+// repositoryNode = raw node of RepositoriesQuery
+type repositoryNode struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	Owner       struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	IsFork          bool      `json:"isFork"`
+	IsPrivate       bool      `json:"isPrivate"`
+	IsArchived      bool      `json:"isArchived"`
+	ForkCount       int       `json:"forkCount"`
+	CreatedAt       time.Time `json:"createdAt"`
+	PushedAt        time.Time `json:"pushedAt"`
+	PrimaryLanguage *struct {
+		Name string `json:"name"`
+	} `json:"primaryLanguage"`
+	Stargazers struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"stargazers"`
+	LicenseInfo *struct {
+		SpdxID string `json:"spdxId"`
+		Name   string `json:"name"`
+	} `json:"licenseInfo"`
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
+}
+
+// repositoriesPage = single page of RepositoriesQuery
+type repositoriesPage struct {
+	Data struct {
+		Owner *struct {
+			Repositories struct {
+				TotalCount int `json:"totalCount"`
+				PageInfo   struct {
+					EndCursor   string `json:"endCursor"`
+					HasNextPage bool   `json:"hasNextPage"`
+				} `json:"pageInfo"`
+				Nodes []repositoryNode `json:"nodes"`
+			} `json:"repositories"`
+		} `json:"repositoryOwner"`
+	} `json:"data"`
+}
+
+func (n repositoryNode) repository() Repository {
+	repo := Repository{
+		Name:        n.Name,
+		Description: n.Description,
+		URL:         n.URL,
+		Owner:       n.Owner.Login,
+		Fork:        n.IsFork,
+		Private:     n.IsPrivate,
+		Archived:    n.IsArchived,
+		Stars:       n.Stargazers.TotalCount,
+		Forks:       n.ForkCount,
+		Topics:      make([]string, 0, len(n.RepositoryTopics.Nodes)),
+		CreatedAt:   n.CreatedAt,
+		PushedAt:    n.PushedAt,
+	}
+	if n.PrimaryLanguage != nil {
+		repo.Language = n.PrimaryLanguage.Name
+	}
+	if n.LicenseInfo != nil {
+		// Custom licenses have no SPDX id
+		repo.License = n.LicenseInfo.SpdxID
+		if repo.License == "" || repo.License == "NOASSERTION" {
+			repo.License = n.LicenseInfo.Name
+		}
+	}
+	for _, t := range n.RepositoryTopics.Nodes {
+		repo.Topics = append(repo.Topics, t.Topic.Name)
+	}
+	return repo
+}
+
+// FetchRepositories = fetch every repository of username selected by the forks, archived,
+// private and affiliation options, the summary options are ignored
+func (c *Client) FetchRepositories(ctx context.Context, username string, opts FetchOptions) ([]Repository, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	var repos []Repository
+	var cursor *string
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		vars := opts.repositoryVariables()
+		vars["username"] = username
+		vars["after"] = cursor
+		data, err := c.FetchGhGql(ctx, RepositoriesQuery, vars)
+		if err != nil {
+			if nf, ok := err.(*NotFoundError); ok {
+				nf.Login = username
+			}
+			return nil, err
+		}
+		b, _ := json.Marshal(data)
+		var page repositoriesPage
+		if err := json.Unmarshal(b, &page); err != nil {
+			return nil, err
+		}
+
+		if page.Data.Owner == nil {
+			return nil, &NotFoundError{Login: username}
+		}
+		conn := page.Data.Owner.Repositories
+		if repos == nil {
+			repos = make([]Repository, 0, conn.TotalCount)
+		}
+		for _, node := range conn.Nodes {
+			if opts.ExcludeArchived && node.IsArchived {
+				continue
+			}
+			repos = append(repos, node.repository())
+		}
+		if !conn.PageInfo.HasNextPage {
+			return repos, nil
+		}
+		cursor = &conn.PageInfo.EndCursor
+	}
+}
+
+// repoLess compares repositories by a single field in ascending order
+var repoLess = map[string]func(a, b *Repository) bool{
+	"name":     func(a, b *Repository) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) },
+	"stars":    func(a, b *Repository) bool { return a.Stars < b.Stars },
+	"forks":    func(a, b *Repository) bool { return a.Forks < b.Forks },
+	"language": func(a, b *Repository) bool { return strings.ToLower(a.Language) < strings.ToLower(b.Language) },
+	"license":  func(a, b *Repository) bool { return strings.ToLower(a.License) < strings.ToLower(b.License) },
+	"created":  func(a, b *Repository) bool { return a.CreatedAt.Before(b.CreatedAt) },
+	"pushed":   func(a, b *Repository) bool { return a.PushedAt.Before(b.PushedAt) },
+}
+
+// RepoListOptions = sorting, filtering and paging of a repository listing.
+// Zero values mean: sorted by stars, descending, no filter, first page of 30.
+type RepoListOptions struct {
+	Sort     string // name, stars, forks, language, license, created or pushed
+	Order    string // asc or desc, defaults to asc for name/language/license and desc otherwise
+	Language string // primary language, case insensitive
+	MinStars int
+	Page     int
+	PerPage  int
+}
+
+// RepoListPage = page of a repository listing, Total counts the repositories matching the filters.
+// An empty listing has a single empty page.
+type RepoListPage struct {
+	Repositories []Repository `json:"repositories"`
+	Total        int          `json:"total"`
+	Page         int          `json:"page"`
+	PerPage      int          `json:"per_page"`
+	Pages        int          `json:"pages"`
+}
+
+// Validate checks the options and fills in the defaults
+func (o *RepoListOptions) Validate() error {
+	if o.Sort == "" {
+		o.Sort = "stars"
+	}
+	if _, ok := repoLess[o.Sort]; !ok {
+		return fmt.Errorf("unknown sort %q, use name, stars, forks, language, license, created or pushed", o.Sort)
+	}
+	switch o.Order {
+	case "":
+		o.Order = "desc"
+		if o.Sort == "name" || o.Sort == "language" || o.Sort == "license" {
+			o.Order = "asc"
+		}
+	case "asc", "desc":
+	default:
+		return fmt.Errorf("unknown order %q, use asc or desc", o.Order)
+	}
+	if o.MinStars < 0 {
+		return fmt.Errorf("min_stars must not be negative")
+	}
+	if o.Page == 0 {
+		o.Page = 1
+	}
+	if o.Page < 0 {
+		return fmt.Errorf("page must be positive")
+	}
+	if o.PerPage == 0 {
+		o.PerPage = defaultReposPerPage
+	}
+	if o.PerPage < 0 || o.PerPage > maxReposPerPage {
+		return fmt.Errorf("per_page must be between 1 and %d", maxReposPerPage)
+	}
+	return nil
+}
+
+// ListRepositories filters, sorts and pages repos, opts must have been validated.
+// Ties are broken by name so pages are stable.
+func ListRepositories(repos []Repository, opts RepoListOptions) RepoListPage {
+	matched := make([]Repository, 0, len(repos))
+	for _, repo := range repos {
+		if opts.Language != "" && !strings.EqualFold(repo.Language, opts.Language) {
+			continue
+		}
+		if repo.Stars < opts.MinStars {
+			continue
+		}
+		matched = append(matched, repo)
+	}
+
+	less := repoLess[opts.Sort]
+	byName := repoLess["name"]
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := &matched[i], &matched[j]
+		if opts.Order == "desc" {
+			a, b = b, a
+		}
+		if less(a, b) {
+			return true
+		}
+		if less(b, a) {
+			return false
+		}
+		return byName(&matched[i], &matched[j])
+	})
+
+	page := RepoListPage{
+		Repositories: []Repository{},
+		Total:        len(matched),
+		Page:         opts.Page,
+		PerPage:      opts.PerPage,
+		Pages:        (len(matched) + opts.PerPage - 1) / opts.PerPage,
+	}
+	if page.Pages == 0 {
+		page.Pages = 1
+	}
+	if start := (opts.Page - 1) * opts.PerPage; start < len(matched) {
+		end := start + opts.PerPage
+		if end > len(matched) {
+			end = len(matched)
+		}
+		page.Repositories = matched[start:end]
+	}
+	return page
+}
+
+// RankRepositories returns the best n repositories by the metric (stars, forks or pushed),
+// ties are broken by stars then name
+func RankRepositories(repos []Repository, by string, n int) []Repository {
+	ranked := make([]Repository, len(repos))
+	copy(ranked, repos)
+
+	less := repoLess[by]
+	byStars := repoLess["stars"]
+	byName := repoLess["name"]
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := &ranked[i], &ranked[j]
+		switch {
+		case less(b, a):
+			return true
+		case less(a, b):
+			return false
+		case byStars(b, a):
+			return true
+		case byStars(a, b):
+			return false
+		}
+		return byName(a, b)
+	})
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
@@ -0,0 +1,79 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func repoNames(repos []Repository) []string {
+	names := []string{}
+	for _, r := range repos {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func TestListRepositoriesPaging(t *testing.T) {
+	repos := []Repository{
+		{Name: "b", Stars: 10},
+		{Name: "A", Stars: 10},
+		{Name: "c", Stars: 30},
+	}
+	tests := []struct {
+		name  string
+		opts  RepoListOptions
+		want  []string
+		total int
+		pages int
+	}{
+		{"stars desc, ties by name", RepoListOptions{}, []string{"c", "A", "b"}, 3, 1},
+		{"second page", RepoListOptions{PerPage: 2, Page: 2}, []string{"b"}, 3, 2},
+		{"past the last page", RepoListOptions{PerPage: 2, Page: 5}, []string{}, 3, 2},
+		{"no match", RepoListOptions{MinStars: 100}, []string{}, 0, 1},
+		{"name asc", RepoListOptions{Sort: "name"}, []string{"A", "b", "c"}, 3, 1},
+	}
+	for _, tt := range tests {
+		opts := tt.opts
+		if err := opts.Validate(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		page := ListRepositories(repos, opts)
+		if got := repoNames(page.Repositories); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: repositories = %v, want %v", tt.name, got, tt.want)
+		}
+		if page.Total != tt.total || page.Pages != tt.pages {
+			t.Errorf("%s: total %d pages %d, want %d and %d", tt.name, page.Total, page.Pages, tt.total, tt.pages)
+		}
+	}
+}
+
+func TestMoreStarredTieBreak(t *testing.T) {
+	edge := func(name string, stars int) *UserRepositoryEdge {
+		e := &UserRepositoryEdge{}
+		e.Node.Name = name
+		e.Node.Stargazers.TotalCount = stars
+		return e
+	}
+	tests := []struct {
+		a, b *UserRepositoryEdge
+		want bool
+	}{
+		{edge("a", 2), edge("b", 1), true},
+		{edge("b", 1), edge("a", 2), false},
+		{edge("alpha", 1), edge("Beta", 1), true},
+		{edge("Beta", 1), edge("alpha", 1), false},
+		{edge("Alpha", 1), edge("alpha", 1), false},
+	}
+	for _, tt := range tests {
+		if got := moreStarred(tt.a, tt.b); got != tt.want {
+			t.Errorf("moreStarred(%s/%d, %s/%d) = %v, want %v", tt.a.Node.Name, tt.a.Node.Stargazers.TotalCount,
+				tt.b.Node.Name, tt.b.Node.Stargazers.TotalCount, got, tt.want)
+		}
+	}
+
+	// TopRepo and TopRepos agree on ties
+	ranked := RankRepositories([]Repository{{Name: "beta", Stars: 1}, {Name: "Alpha", Stars: 1}}, "stars", 1)
+	if ranked[0].Name != "Alpha" {
+		t.Errorf("RankRepositories picked %s, want Alpha", ranked[0].Name)
+	}
+}
@@ -0,0 +1,124 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy = how transient GitHub failures are retried inside the client
+//
+// Attempt n (starting at 0) waits a random duration between 0 and
+// min(MaxDelay, BaseDelay*2^n) before the next one ("full jitter").
+type RetryPolicy struct {
+	// MaxAttempts includes the first request, values below 2 disable retries
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// RetryableStatus lists HTTP statuses worth another attempt
+	RetryableStatus []int
+	// Retryable overrides the default error classification when set
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy retries 5xx gateway errors and dropped connections up to 4 times
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		RetryableStatus: []int{
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p *RetryPolicy) attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns the jittered delay before retrying after the given attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := p.MaxDelay
+	if d := p.BaseDelay << uint(attempt); d > 0 && d < ceiling {
+		ceiling = d
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling)))
+}
+
+// retryable reports whether err is worth another attempt
+func (p *RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		for _, status := range p.RetryableStatus {
+			if httpErr.StatusCode == status {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Secondary rate limits ask for a short pause, the limiter already waits for it
+	var rateLimit *RateLimitError
+	if errors.As(err, &rateLimit) {
+		return rateLimit.RetryAfter > 0 && rateLimit.RetryAfter <= p.MaxDelay
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// ClientStats = request counters of a Client, e.g. for exporting as metrics
+type ClientStats struct {
+	Requests int64
+	Retries  int64
+	Failures int64
+}
+
+type clientStats struct {
+	mu    sync.Mutex
+	stats ClientStats
+}
+
+func (s *clientStats) add(requests, retries, failures int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Requests += requests
+	s.stats.Retries += retries
+	s.stats.Failures += failures
+}
+
+// Stats returns the counters since the client was created
+func (c *Client) Stats() ClientStats {
+	c.stats.mu.Lock()
+	defer c.stats.mu.Unlock()
+	return c.stats.stats
+}
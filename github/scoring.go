@@ -0,0 +1,199 @@
+package github
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Ranking metrics of the top stars, see ScoreOptions.By
+const (
+	ScoreStars        = "stars"
+	ScoreForks        = "forks"
+	ScoreFollowers    = "followers"
+	ScoreComposite    = "composite"
+	ScoreStarsPerRepo = "stars_per_repo"
+)
+
+// Weights of the composite score
+type Weights struct {
+	Stars     float64 `json:"stars"`
+	Forks     float64 `json:"forks"`
+	Followers float64 `json:"followers"`
+}
+
+// DefaultWeights are used by the composite score when no weight is set
+var DefaultWeights = Weights{Stars: 1, Forks: 1, Followers: 1}
+
+// MaxWeight is the largest weight of the composite score
+const MaxWeight = 1e6
+
+// Validate checks every weight is a number between 0 and MaxWeight and one of them at least is set
+func (w Weights) Validate() error {
+	for _, f := range []float64{w.Stars, w.Forks, w.Followers} {
+		// NaN fails every comparison
+		if !(f >= 0 && f <= MaxWeight) {
+			return fmt.Errorf("weights must be between 0 and %g", float64(MaxWeight))
+		}
+	}
+	if w == (Weights{}) {
+		return fmt.Errorf("weights must not all be 0")
+	}
+	return nil
+}
+
+// ParseWeights reads weights such as "stars:1,forks:2,followers:0.5", missing ones are 0
+func ParseWeights(value string) (Weights, error) {
+	var w Weights
+	for _, part := range strings.Split(value, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), ":", 2)
+		if len(kv) != 2 {
+			return w, fmt.Errorf("invalid weight %q, use e.g. stars:1,forks:2,followers:0.5", part)
+		}
+		f, err := strconv.ParseFloat(kv[1], 64)
+		if err != nil {
+			return w, fmt.Errorf("invalid weight %q, use e.g. stars:1,forks:2,followers:0.5", part)
+		}
+		switch kv[0] {
+		case "stars":
+			w.Stars = f
+		case "forks":
+			w.Forks = f
+		case "followers":
+			w.Followers = f
+		default:
+			return w, fmt.Errorf("unknown weight %q, use stars, forks or followers", kv[0])
+		}
+	}
+	return w, w.Validate()
+}
+
+// String formats the weights the way ParseWeights reads them
+func (w Weights) String() string {
+	format := func(f float64) string { return strconv.FormatFloat(f, 'g', -1, 64) }
+	return "stars:" + format(w.Stars) + ",forks:" + format(w.Forks) + ",followers:" + format(w.Followers)
+}
+
+// ScoreOptions = how the top stars developers are scored and which ones are ranked.
+// Zero values mean: ranked by stars, no minimum.
+type ScoreOptions struct {
+	By string // stars, forks, followers, composite or stars_per_repo
+
+	MinStars     int
+	MinForks     int
+	MinFollowers int
+	MinRepos     int
+
+	// Weights of the composite score, the zero value means none were given and
+	// defaults to DefaultWeights. Weights given explicitly are checked by Weights.Validate.
+	Weights Weights
+}
+
+// Validate checks the options and fills in the defaults
+func (o *ScoreOptions) Validate() error {
+	switch o.By {
+	case "":
+		o.By = ScoreStars
+	case ScoreStars, ScoreForks, ScoreFollowers, ScoreComposite, ScoreStarsPerRepo:
+	default:
+		return fmt.Errorf("unknown score %q, use stars, forks, followers, composite or stars_per_repo", o.By)
+	}
+	if o.MinStars < 0 || o.MinForks < 0 || o.MinFollowers < 0 || o.MinRepos < 0 {
+		return fmt.Errorf("minimums must not be negative")
+	}
+	if o.Weights == (Weights{}) {
+		o.Weights = DefaultWeights
+	}
+	return o.Weights.Validate()
+}
+
+// DevCounts = what a developer is scored by
+type DevCounts struct {
+	Login     string
+	Stars     int
+	Forks     int
+	Followers int
+	Repos     int
+}
+
+// Score returns the score of a developer, opts must have been validated
+func (o ScoreOptions) Score(c DevCounts) float64 {
+	switch o.By {
+	case ScoreForks:
+		return float64(c.Forks)
+	case ScoreFollowers:
+		return float64(c.Followers)
+	case ScoreComposite:
+		return o.Weights.Stars*float64(c.Stars) + o.Weights.Forks*float64(c.Forks) + o.Weights.Followers*float64(c.Followers)
+	case ScoreStarsPerRepo:
+		if c.Repos == 0 {
+			return 0
+		}
+		return float64(c.Stars) / float64(c.Repos)
+	}
+	return float64(c.Stars)
+}
+
+func (o ScoreOptions) qualifies(c DevCounts) bool {
+	return c.Stars >= o.MinStars && c.Forks >= o.MinForks &&
+		c.Followers >= o.MinFollowers && c.Repos >= o.MinRepos
+}
+
+// DevScore = position of a ranked developer in the slice given to Rank
+type DevScore struct {
+	Index int
+	Score float64
+}
+
+// Rank returns the developers meeting the minimums best first, ordered by score
+// then stars then login so equal scores always rank the same way. opts must have been validated.
+func (o ScoreOptions) Rank(devs []DevCounts) []DevScore {
+	ranked := make([]DevScore, 0, len(devs))
+	for i, c := range devs {
+		if o.qualifies(c) {
+			ranked = append(ranked, DevScore{Index: i, Score: o.Score(c)})
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		ca, cb := devs[a.Index], devs[b.Index]
+		if ca.Stars != cb.Stars {
+			return ca.Stars > cb.Stars
+		}
+		return strings.ToLower(ca.Login) < strings.ToLower(cb.Login)
+	})
+	return ranked
+}
+
+// Counts returns what the developer is scored by
+func (d DevStar) Counts() DevCounts {
+	c := DevCounts{Stars: d.Stars, Forks: d.Forks, Repos: d.Repos}
+	if d.Dev != nil {
+		c.Login = d.Dev.Node.Login
+		c.Followers = d.Dev.Node.Follower.TotalCount
+	}
+	return c
+}
+
+// RankDevStars returns the developers meeting the minimums of opts ranked by their score,
+// rank movement is left to the caller. opts must have been validated.
+func RankDevStars(devs []DevStar, opts ScoreOptions) []DevStar {
+	counts := make([]DevCounts, len(devs))
+	for i, dev := range devs {
+		counts[i] = dev.Counts()
+	}
+	ranked := make([]DevStar, 0, len(devs))
+	for i, ds := range opts.Rank(counts) {
+		dev := devs[ds.Index]
+		dev.Score = ds.Score
+		dev.Rank = i + 1
+		dev.PreviousRank = 0
+		dev.StarsDelta = 0
+		ranked = append(ranked, dev)
+	}
+	return ranked
+}
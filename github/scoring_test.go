@@ -0,0 +1,119 @@
+package github
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseWeights(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Weights
+		wantErr bool
+	}{
+		{"stars:1,forks:2,followers:0.5", Weights{1, 2, 0.5}, false},
+		{"followers:3", Weights{0, 0, 3}, false},
+		{" stars:1 , forks:0", Weights{1, 0, 0}, false},
+		{"stars:1e6", Weights{1e6, 0, 0}, false},
+		{"stars:0,forks:0,followers:0", Weights{}, true},
+		{"stars:-1", Weights{}, true},
+		{"stars:NaN", Weights{}, true},
+		{"stars:Inf", Weights{}, true},
+		{"stars:-Inf,forks:1", Weights{}, true},
+		{"stars:1e7", Weights{}, true},
+		{"stars", Weights{}, true},
+		{"stars:x", Weights{}, true},
+		{"issues:1", Weights{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWeights(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseWeights(%q) err = %v, want error %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseWeights(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestScoreOptionsValidate(t *testing.T) {
+	opts := ScoreOptions{}
+	if err := opts.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if opts.By != ScoreStars || opts.Weights != DefaultWeights {
+		t.Errorf("defaults = %+v", opts)
+	}
+
+	bad := []ScoreOptions{
+		{By: "issues"},
+		{MinStars: -1},
+		{MinRepos: -1},
+		{By: ScoreComposite, Weights: Weights{Stars: math.NaN()}},
+		{By: ScoreComposite, Weights: Weights{Stars: 1, Forks: math.Inf(1)}},
+		{By: ScoreComposite, Weights: Weights{Followers: -2}},
+	}
+	for _, opts := range bad {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", opts)
+		}
+	}
+}
+
+func TestScoreOptionsRank(t *testing.T) {
+	devs := []DevCounts{
+		{Login: "carol", Stars: 100, Forks: 10, Followers: 5, Repos: 4},
+		{Login: "Bob", Stars: 100, Forks: 10, Followers: 50, Repos: 2},
+		{Login: "alice", Stars: 100, Forks: 30, Followers: 5, Repos: 10},
+		{Login: "dave", Stars: 40, Forks: 30, Followers: 500, Repos: 1},
+		{Login: "erin", Stars: 0, Forks: 0, Followers: 0, Repos: 0},
+	}
+	tests := []struct {
+		name string
+		opts ScoreOptions
+		want []string
+	}{
+		// Equal stars fall back to the login, case-insensitively
+		{"stars", ScoreOptions{}, []string{"alice", "Bob", "carol", "dave", "erin"}},
+		// Equal forks fall back to stars, then the login
+		{"forks", ScoreOptions{By: ScoreForks}, []string{"alice", "dave", "Bob", "carol", "erin"}},
+		{"followers", ScoreOptions{By: ScoreFollowers}, []string{"dave", "Bob", "alice", "carol", "erin"}},
+		{"stars per repo, no repos scores 0", ScoreOptions{By: ScoreStarsPerRepo}, []string{"Bob", "dave", "carol", "alice", "erin"}},
+		{"composite", ScoreOptions{By: ScoreComposite, Weights: Weights{Stars: 1, Forks: 2}},
+			[]string{"alice", "Bob", "carol", "dave", "erin"}},
+		{"composite followers only", ScoreOptions{By: ScoreComposite, Weights: Weights{Followers: 1}},
+			[]string{"dave", "Bob", "alice", "carol", "erin"}},
+		{"minimums", ScoreOptions{MinStars: 50, MinFollowers: 10}, []string{"Bob"}},
+		{"min repos", ScoreOptions{MinRepos: 4}, []string{"alice", "carol"}},
+	}
+	for _, tt := range tests {
+		opts := tt.opts
+		if err := opts.Validate(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var got []string
+		for _, ds := range opts.Rank(devs) {
+			got = append(got, devs[ds.Index].Login)
+			if ds.Score != opts.Score(devs[ds.Index]) {
+				t.Errorf("%s: score of %s = %g, want %g", tt.name, devs[ds.Index].Login, ds.Score, opts.Score(devs[ds.Index]))
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ranked %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// The order of the input doesn't matter
+	reversed := make([]DevCounts, len(devs))
+	for i, c := range devs {
+		reversed[len(devs)-1-i] = c
+	}
+	opts := ScoreOptions{}
+	opts.Validate()
+	first := reversed[opts.Rank(reversed)[0].Index].Login
+	if first != "alice" {
+		t.Errorf("reversed input ranked %s first, want alice", first)
+	}
+}
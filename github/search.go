@@ -0,0 +1,252 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const maxQualifierLength = 64
+
+var (
+	// Letters of any script, digits and the punctuation found in place names, e.g. "Daerah Istimewa Yogyakarta"
+	locationRe = regexp.MustCompile(`^[\pL\pN][\pL\pN .,'-]*$`)
+	// Linguist names, e.g. "C++", "C#", "Jupyter Notebook", "Objective-C"
+	languageRe = regexp.MustCompile(`^(\*|[\pL\pN][\pL\pN +#.'-]*)$`)
+	// Count or range: 100, >=100, <50, 10..50, 10..*, *..50
+	rangeRe = regexp.MustCompile(`^((>=|<=|>|<)?[0-9]+|[0-9]+\.\.([0-9]+|\*)|\*\.\.[0-9]+)$`)
+)
+
+// QualifierError - search qualifier value which can't be used safely
+type QualifierError struct {
+	Qualifier string
+	Value     string
+}
+
+func (e *QualifierError) Error() string {
+	return fmt.Sprintf("invalid %s qualifier %q", e.Qualifier, e.Value)
+}
+
+// SearchQuery builds the GitHub user search string of the segment,
+// e.g. `location:"New York" language:Go followers:>=100`.
+// The result is meant to be sent as GraphQL variable, never spliced into a query.
+func (s Segment) SearchQuery() (string, error) {
+	var qualifiers []string
+
+	if s.Location != "" {
+		q, err := qualifier("location", s.Location, locationRe)
+		if err != nil {
+			return "", err
+		}
+		qualifiers = append(qualifiers, q)
+	}
+	if s.Language != "" && s.Language != "*" {
+		q, err := qualifier("language", s.Language, languageRe)
+		if err != nil {
+			return "", err
+		}
+		qualifiers = append(qualifiers, q)
+	}
+	if s.Followers != "" {
+		q, err := qualifier("followers", s.Followers, rangeRe)
+		if err != nil {
+			return "", err
+		}
+		if _, err := parseFollowerRange(s.Followers); err != nil {
+			return "", err
+		}
+		qualifiers = append(qualifiers, q)
+	}
+	if len(qualifiers) == 0 {
+		return "", fmt.Errorf("segment %s has no search qualifier", s.Name)
+	}
+	return strings.Join(qualifiers, " "), nil
+}
+
+// qualifier validates value and quotes it when it contains spaces
+func qualifier(name, value string, re *regexp.Regexp) (string, error) {
+	value = strings.TrimSpace(value)
+	if len(value) > maxQualifierLength || !re.MatchString(value) {
+		return "", &QualifierError{Qualifier: name, Value: value}
+	}
+	if strings.Contains(value, " ") {
+		value = `"` + value + `"`
+	}
+	return name + ":" + value, nil
+}
+
+// maxSearchResults is the number of results GitHub search returns at most per query
+const maxSearchResults = 1000
+
+// followerRange = inclusive follower count range, Max < 0 means unbounded
+type followerRange struct {
+	Min int
+	Max int
+}
+
+// parseFollowerRange converts a followers qualifier (already validated by rangeRe) into a range,
+// a range no count can match such as <0 or 50..10 is rejected
+func parseFollowerRange(value string) (followerRange, error) {
+	value = strings.TrimSpace(value)
+	atoi := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	var r followerRange
+	switch {
+	case value == "":
+		r = followerRange{Min: 0, Max: -1}
+	case strings.Contains(value, ".."):
+		parts := strings.SplitN(value, "..", 2)
+		r = followerRange{Min: 0, Max: -1}
+		if parts[0] != "*" {
+			r.Min = atoi(parts[0])
+		}
+		if parts[1] != "*" {
+			if r.Max = atoi(parts[1]); r.Max < r.Min {
+				return r, &QualifierError{Qualifier: "followers", Value: value}
+			}
+		}
+	case strings.HasPrefix(value, ">="):
+		r = followerRange{Min: atoi(value[2:]), Max: -1}
+	case strings.HasPrefix(value, "<="):
+		r = followerRange{Min: 0, Max: atoi(value[2:])}
+	case strings.HasPrefix(value, ">"):
+		r = followerRange{Min: atoi(value[1:]) + 1, Max: -1}
+	case strings.HasPrefix(value, "<"):
+		// Max < 0 means unbounded, <0 must not turn into >=0
+		if r = (followerRange{Min: 0, Max: atoi(value[1:]) - 1}); r.Max < 0 {
+			return r, &QualifierError{Qualifier: "followers", Value: value}
+		}
+	default:
+		n := atoi(value)
+		r = followerRange{Min: n, Max: n}
+	}
+	return r, nil
+}
+
+func (r followerRange) String() string {
+	if r.Max < 0 {
+		return ">=" + strconv.Itoa(r.Min)
+	}
+	return strconv.Itoa(r.Min) + ".." + strconv.Itoa(r.Max)
+}
+
+// split halves the range, the upper half comes first so the most followed users are crawled first
+func (r followerRange) split() (followerRange, followerRange, bool) {
+	if r.Max < 0 {
+		mid := r.Min * 2
+		if mid <= r.Min {
+			mid = r.Min + 1
+		}
+		return followerRange{Min: mid, Max: -1}, followerRange{Min: r.Min, Max: mid - 1}, true
+	}
+	if r.Max <= r.Min {
+		return r, r, false
+	}
+	mid := (r.Min + r.Max) / 2
+	return followerRange{Min: mid + 1, Max: r.Max}, followerRange{Min: r.Min, Max: mid}, true
+}
+
+// searchPage - single page of SearchUsersQuery
+type searchPage struct {
+	Data struct {
+		Search struct {
+			UserCount int `json:"userCount"`
+			PageInfo  struct {
+				EndCursor   string `json:"endCursor"`
+				HasNextPage bool   `json:"hasNextPage"`
+			} `json:"pageInfo"`
+			Edges []SummaryDev `json:"edges"`
+		} `json:"search"`
+	} `json:"data"`
+}
+
+func (c *Client) fetchSearchPage(ctx context.Context, q string, first int, after *string) (*searchPage, error) {
+	data, err := c.FetchGhGql(ctx, SearchUsersQuery, map[string]interface{}{
+		"q":     q,
+		"first": first,
+		"after": after,
+	})
+	if err != nil {
+		return nil, err
+	}
+	b, _ := json.Marshal(data)
+	var page searchPage
+	if err := json.Unmarshal(b, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// segmentSearch collects users of a segment across pages and follower ranges
+type segmentSearch struct {
+	client  *Client
+	segment Segment
+	limit   int
+	devs    []SummaryDev
+	seen    map[string]bool
+}
+
+func (s *segmentSearch) full() bool {
+	return len(s.devs) >= s.limit
+}
+
+// collect follows the cursors of a single query until it's exhausted,
+// GitHub stops at maxSearchResults or the limit is reached
+func (s *segmentSearch) collect(ctx context.Context, q string, first *searchPage) error {
+	page := first
+	for {
+		for _, dev := range page.Data.Search.Edges {
+			// Organizations match USER searches too, they come back as empty nodes
+			if dev.Node.Login == "" || s.seen[dev.Node.Login] {
+				continue
+			}
+			s.seen[dev.Node.Login] = true
+			s.devs = append(s.devs, dev)
+			if s.full() {
+				return nil
+			}
+		}
+		if !page.Data.Search.PageInfo.HasNextPage {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		cursor := page.Data.Search.PageInfo.EndCursor
+		next, err := s.client.fetchSearchPage(ctx, q, s.segment.Size, &cursor)
+		if err != nil {
+			return err
+		}
+		page = next
+	}
+}
+
+// searchRange searches the segment restricted to the follower range,
+// splitting it while it matches more users than GitHub search returns
+func (s *segmentSearch) searchRange(ctx context.Context, r followerRange) error {
+	seg := s.segment
+	seg.Followers = r.String()
+	q, err := seg.SearchQuery()
+	if err != nil {
+		return err
+	}
+	page, err := s.client.fetchSearchPage(ctx, q, seg.Size, nil)
+	if err != nil {
+		return err
+	}
+
+	if page.Data.Search.UserCount > maxSearchResults {
+		if upper, lower, ok := r.split(); ok {
+			if err := s.searchRange(ctx, upper); err != nil || s.full() {
+				return err
+			}
+			return s.searchRange(ctx, lower)
+		}
+	}
+	return s.collect(ctx, q, page)
+}
@@ -0,0 +1,201 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFollowerRange(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    followerRange
+		wantErr bool
+	}{
+		{"", followerRange{0, -1}, false},
+		{"100", followerRange{100, 100}, false},
+		{">=100", followerRange{100, -1}, false},
+		{">100", followerRange{101, -1}, false},
+		{"<=50", followerRange{0, 50}, false},
+		{"<50", followerRange{0, 49}, false},
+		{"<1", followerRange{0, 0}, false},
+		{"10..50", followerRange{10, 50}, false},
+		{"10..*", followerRange{10, -1}, false},
+		{"*..50", followerRange{0, 50}, false},
+		{"5..5", followerRange{5, 5}, false},
+		{"<0", followerRange{}, true},
+		{"50..10", followerRange{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseFollowerRange(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseFollowerRange(%q) err = %v, want error %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseFollowerRange(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFollowerRangeSplit(t *testing.T) {
+	tests := []struct {
+		r            followerRange
+		upper, lower followerRange
+		ok           bool
+	}{
+		{followerRange{100, -1}, followerRange{200, -1}, followerRange{100, 199}, true},
+		{followerRange{0, -1}, followerRange{1, -1}, followerRange{0, 0}, true},
+		{followerRange{1, -1}, followerRange{2, -1}, followerRange{1, 1}, true},
+		{followerRange{10, 20}, followerRange{16, 20}, followerRange{10, 15}, true},
+		{followerRange{10, 11}, followerRange{11, 11}, followerRange{10, 10}, true},
+		{followerRange{7, 7}, followerRange{7, 7}, followerRange{7, 7}, false},
+	}
+	for _, tt := range tests {
+		upper, lower, ok := tt.r.split()
+		if ok != tt.ok || upper != tt.upper || lower != tt.lower {
+			t.Errorf("%+v.split() = %+v, %+v, %v, want %+v, %+v, %v", tt.r, upper, lower, ok, tt.upper, tt.lower, tt.ok)
+		}
+	}
+}
+
+func TestFollowerRangeString(t *testing.T) {
+	tests := []struct {
+		r    followerRange
+		want string
+	}{
+		{followerRange{100, -1}, ">=100"},
+		{followerRange{10, 20}, "10..20"},
+		{followerRange{0, 0}, "0..0"},
+	}
+	for _, tt := range tests {
+		if got := tt.r.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+}
+
+func TestSearchQueryRejectsEmptyRange(t *testing.T) {
+	seg := Segment{Name: "s", Location: "Jakarta", Followers: "<0", Size: 10}
+	if _, err := seg.SearchQuery(); err == nil {
+		t.Error("followers:<0 was accepted")
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		seg     Segment
+		want    string
+		wantErr string
+	}{
+		{"single word", Segment{Location: "Jakarta"}, "location:Jakarta", ""},
+		{"multi word location is quoted", Segment{Location: "New York"}, `location:"New York"`, ""},
+		{"all qualifiers", Segment{Location: "Daerah Istimewa Yogyakarta", Language: "C++", Followers: ">=100"},
+			`location:"Daerah Istimewa Yogyakarta" language:C++ followers:>=100`, ""},
+		{"any language", Segment{Location: "Bandung", Language: "*"}, "location:Bandung", ""},
+		{"non latin location", Segment{Location: "東京"}, "location:東京", ""},
+		{"punctuation of place names", Segment{Location: "St. John's, Newfoundland-Labrador"}, `location:"St. John's, Newfoundland-Labrador"`, ""},
+		{"surrounding spaces are trimmed", Segment{Location: " Jakarta\n"}, "location:Jakarta", ""},
+		{"language with spaces", Segment{Language: "Jupyter Notebook"}, `language:"Jupyter Notebook"`, ""},
+
+		{"quote breaking out", Segment{Location: `Jakarta" language:Go`}, "", "location"},
+		{"extra qualifier", Segment{Location: "Jakarta language:Go"}, "", "location"},
+		{"embedded colon", Segment{Location: "location:Bandung"}, "", "location"},
+		{"quote", Segment{Location: `"Jakarta"`}, "", "location"},
+		{"newline", Segment{Location: "Jakarta\nfollowers:>1"}, "", "location"},
+		{"control character", Segment{Location: "Jakarta\x00"}, "", "location"},
+		{"tab", Segment{Location: "New\tYork"}, "", "location"},
+		{"over long", Segment{Location: strings.Repeat("a", maxQualifierLength+1)}, "", "location"},
+		{"boolean operator", Segment{Location: "Jakarta OR"}, `location:"Jakarta OR"`, ""},
+		{"language qualifier", Segment{Location: "Jakarta", Language: "Go sort:followers"}, "", "language"},
+		{"language quote", Segment{Location: "Jakarta", Language: `Go"`}, "", "language"},
+		{"followers text", Segment{Location: "Jakarta", Followers: ">=100 location:x"}, "", "followers"},
+		{"followers syntax", Segment{Location: "Jakarta", Followers: "=>100"}, "", "followers"},
+		{"followers negative", Segment{Location: "Jakarta", Followers: "-5"}, "", "followers"},
+		{"followers open range", Segment{Location: "Jakarta", Followers: "*..*"}, "", "followers"},
+		{"followers decimal", Segment{Location: "Jakarta", Followers: "1.5"}, "", "followers"},
+		{"no qualifier", Segment{Name: "empty"}, "", "no search qualifier"},
+	}
+	for _, tt := range tests {
+		got, err := tt.seg.SearchQuery()
+		if tt.wantErr != "" {
+			var qErr *QualifierError
+			if err == nil || (tt.wantErr != "no search qualifier" && (!errors.As(err, &qErr) || qErr.Qualifier != tt.wantErr)) ||
+				!strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: SearchQuery() = %q, %v, want a %s error", tt.name, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s: SearchQuery() = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+// graphQLRequest = body of a GraphQL request as received by GitHub
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+func TestSearchSentAsVariable(t *testing.T) {
+	var got []graphQLRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		got = append(got, req)
+		w.Write([]byte(`{"data":{"search":{"userCount":0,"edges":[]}}}`))
+	}))
+	defer srv.Close()
+	c := newTestClient(srv.URL, 1, time.Millisecond)
+
+	seg := Segment{Name: "newYork", Location: "New York", Language: "Go", Followers: ">=10", Size: 10}
+	if _, err := c.FetchSegment(context.Background(), seg); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("%d requests, want 1", len(got))
+	}
+	want := `location:"New York" language:Go followers:>=10`
+	if q := got[0].Variables["q"]; q != want {
+		t.Errorf("$q = %#v, want %q", q, want)
+	}
+	if got[0].Query != SearchUsersQuery {
+		t.Errorf("query text was changed for the segment:\n%s", got[0].Query)
+	}
+
+	// The summary query only gets one parameter per segment, named after it
+	query, vars, err := BuildSummaryQuery([]Segment{seg, {Name: "jakarta", Location: "Jakarta", Size: 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vars["newYork"] != want || vars["jakarta"] != "location:Jakarta" {
+		t.Errorf("variables = %v", vars)
+	}
+	for _, value := range []string{"New York", "Jakarta", "location:", ">=10"} {
+		if strings.Contains(query, value) {
+			t.Errorf("summary query text contains %q:\n%s", value, query)
+		}
+	}
+	if !strings.Contains(query, "$newYork: String!") || !strings.Contains(query, "$jakarta: String!") {
+		t.Errorf("summary query doesn't declare the segment variables:\n%s", query)
+	}
+
+	// Rejected segments never reach GitHub
+	bad := Segment{Name: "bad", Location: `Jakarta" language:Go`, Size: 10}
+	if _, err := c.FetchSegment(context.Background(), bad); err == nil {
+		t.Error("injected location was accepted")
+	}
+	if _, _, err := BuildSummaryQuery([]Segment{bad}); err == nil {
+		t.Error("injected location was accepted by the summary")
+	}
+	if len(got) != 1 {
+		t.Errorf("%d requests, the rejected segment was sent", len(got))
+	}
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Developer = public profile of a developer found by a segment search
+type Developer struct {
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+	Bio       string `json:"bio"`
+	Company   string `json:"company"`
+	Location  string `json:"location"`
+	Followers int    `json:"followers"`
+	Following int    `json:"following"`
+}
+
+// SegmentSummary = top developers of a single leaderboard segment
+type SegmentSummary struct {
+	Name       string      `json:"name"`
+	Location   string      `json:"location"`
+	Language   string      `json:"language"`
+	Followers  string      `json:"followers"`
+	Developers []Developer `json:"developers"`
+}
+
+// Summary = top developers of every segment, in leaderboard order
+type Summary struct {
+	Leaderboard string           `json:"leaderboard"`
+	Segments    []SegmentSummary `json:"segments"`
+}
+
+// Developer converts the search result into its stable shape
+func (d SummaryDev) Developer() Developer {
+	return Developer{
+		Login:     d.Node.Login,
+		Name:      d.Node.Name,
+		AvatarURL: d.Node.AvatarURL,
+		Bio:       d.Node.Bio,
+		Company:   d.Node.Company,
+		Location:  d.Node.Location,
+		Followers: d.Node.Follower.TotalCount,
+		Following: d.Node.Following.TotalCount,
+	}
+}
+
+// Developers converts a list of search results, see SummaryDev.Developer
+func Developers(devs []SummaryDev) []Developer {
+	list := make([]Developer, 0, len(devs))
+	for _, dev := range devs {
+		list = append(list, dev.Developer())
+	}
+	return list
+}
+
+// FetchTopUserSummary = fetch top users of every segment of the leaderboard using GQL
+func (c *Client) FetchTopUserSummary(ctx context.Context, board *Leaderboard) (*Summary, error) {
+	query, variables, err := BuildSummaryQuery(board.Summary)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.FetchGhGql(ctx, query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	b, _ := json.Marshal(data)
+	var resp struct {
+		Data map[string]struct {
+			Edges []SummaryDev `json:"edges"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{
+		Leaderboard: board.Name,
+		Segments:    make([]SegmentSummary, 0, len(board.Summary)),
+	}
+	for _, seg := range board.Summary {
+		var devs []SummaryDev
+		// Organizations match USER searches too, they come back as empty nodes
+		for _, dev := range resp.Data[seg.Name].Edges {
+			if dev.Node.Login != "" {
+				devs = append(devs, dev)
+			}
+		}
+		summary.Segments = append(summary.Segments, SegmentSummary{
+			Name:       seg.Name,
+			Location:   seg.Location,
+			Language:   seg.Language,
+			Followers:  seg.Followers,
+			Developers: Developers(devs),
+		})
+	}
+	return summary, nil
+}
@@ -1,9 +1,11 @@
 package model
 
-// ResponsePayload is the basic response payload
+// ResponsePayload is the basic response payload,
+// failed requests carry a human readable Error and a machine readable Code
 type ResponsePayload struct {
 	Data  interface{} `json:"data"`
 	Error string      `json:"error"`
+	Code  string      `json:"code,omitempty"`
 }
 
 // GhGqlPayload is the basic response payload
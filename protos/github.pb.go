@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -26,6 +27,13 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type GithubRequest struct {
 	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	TopN                 int32    `protobuf:"varint,2,opt,name=top_n,json=topN,proto3" json:"top_n,omitempty"`
+	TopBy                string   `protobuf:"bytes,3,opt,name=top_by,json=topBy,proto3" json:"top_by,omitempty"`
+	Languages            bool     `protobuf:"varint,4,opt,name=languages,proto3" json:"languages,omitempty"`
+	IncludeForks         bool     `protobuf:"varint,5,opt,name=include_forks,json=includeForks,proto3" json:"include_forks,omitempty"`
+	ExcludeArchived      bool     `protobuf:"varint,6,opt,name=exclude_archived,json=excludeArchived,proto3" json:"exclude_archived,omitempty"`
+	IncludePrivate       bool     `protobuf:"varint,7,opt,name=include_private,json=includePrivate,proto3" json:"include_private,omitempty"`
+	Affiliations         []string `protobuf:"bytes,8,rep,name=affiliations,proto3" json:"affiliations,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -63,73 +71,1519 @@ func (m *GithubRequest) GetUsername() string {
 	return ""
 }
 
+func (m *GithubRequest) GetTopN() int32 {
+	if m != nil {
+		return m.TopN
+	}
+	return 0
+}
+
+func (m *GithubRequest) GetTopBy() string {
+	if m != nil {
+		return m.TopBy
+	}
+	return ""
+}
+
+func (m *GithubRequest) GetLanguages() bool {
+	if m != nil {
+		return m.Languages
+	}
+	return false
+}
+
+func (m *GithubRequest) GetIncludeForks() bool {
+	if m != nil {
+		return m.IncludeForks
+	}
+	return false
+}
+
+func (m *GithubRequest) GetExcludeArchived() bool {
+	if m != nil {
+		return m.ExcludeArchived
+	}
+	return false
+}
+
+func (m *GithubRequest) GetIncludePrivate() bool {
+	if m != nil {
+		return m.IncludePrivate
+	}
+	return false
+}
+
+func (m *GithubRequest) GetAffiliations() []string {
+	if m != nil {
+		return m.Affiliations
+	}
+	return nil
+}
+
 type GithubResponse struct {
 	Username             string           `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 	Starcount            int32            `protobuf:"varint,2,opt,name=starcount,proto3" json:"starcount,omitempty"`
 	Repocount            int32            `protobuf:"varint,3,opt,name=repocount,proto3" json:"repocount,omitempty"`
 	Forkcount            int32            `protobuf:"varint,4,opt,name=forkcount,proto3" json:"forkcount,omitempty"`
 	Langmap              map[string]int32 `protobuf:"bytes,5,rep,name=langmap,proto3" json:"langmap,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	TopRepos             []*Repository    `protobuf:"bytes,6,rep,name=top_repos,json=topRepos,proto3" json:"top_repos,omitempty"`
+	Languages            []*LanguageShare `protobuf:"bytes,7,rep,name=languages,proto3" json:"languages,omitempty"`
+	OwnerType            string           `protobuf:"bytes,8,opt,name=owner_type,json=ownerType,proto3" json:"owner_type,omitempty"`
+	MemberCount          int32            `protobuf:"varint,9,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *GithubResponse) Reset()         { *m = GithubResponse{} }
-func (m *GithubResponse) String() string { return proto.CompactTextString(m) }
-func (*GithubResponse) ProtoMessage()    {}
-func (*GithubResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_da726d3ccdb16248, []int{1}
+func (m *GithubResponse) Reset()         { *m = GithubResponse{} }
+func (m *GithubResponse) String() string { return proto.CompactTextString(m) }
+func (*GithubResponse) ProtoMessage()    {}
+func (*GithubResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{1}
+}
+
+func (m *GithubResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GithubResponse.Unmarshal(m, b)
+}
+func (m *GithubResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GithubResponse.Marshal(b, m, deterministic)
+}
+func (m *GithubResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GithubResponse.Merge(m, src)
+}
+func (m *GithubResponse) XXX_Size() int {
+	return xxx_messageInfo_GithubResponse.Size(m)
+}
+func (m *GithubResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GithubResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GithubResponse proto.InternalMessageInfo
+
+func (m *GithubResponse) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *GithubResponse) GetStarcount() int32 {
+	if m != nil {
+		return m.Starcount
+	}
+	return 0
+}
+
+func (m *GithubResponse) GetRepocount() int32 {
+	if m != nil {
+		return m.Repocount
+	}
+	return 0
+}
+
+func (m *GithubResponse) GetForkcount() int32 {
+	if m != nil {
+		return m.Forkcount
+	}
+	return 0
+}
+
+func (m *GithubResponse) GetLangmap() map[string]int32 {
+	if m != nil {
+		return m.Langmap
+	}
+	return nil
+}
+
+func (m *GithubResponse) GetTopRepos() []*Repository {
+	if m != nil {
+		return m.TopRepos
+	}
+	return nil
+}
+
+func (m *GithubResponse) GetLanguages() []*LanguageShare {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+func (m *GithubResponse) GetOwnerType() string {
+	if m != nil {
+		return m.OwnerType
+	}
+	return ""
+}
+
+func (m *GithubResponse) GetMemberCount() int32 {
+	if m != nil {
+		return m.MemberCount
+	}
+	return 0
+}
+
+type LanguageShare struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Color                string   `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"`
+	Bytes                int64    `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Percent              float64  `protobuf:"fixed64,4,opt,name=percent,proto3" json:"percent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LanguageShare) Reset()         { *m = LanguageShare{} }
+func (m *LanguageShare) String() string { return proto.CompactTextString(m) }
+func (*LanguageShare) ProtoMessage()    {}
+func (*LanguageShare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{2}
+}
+
+func (m *LanguageShare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LanguageShare.Unmarshal(m, b)
+}
+func (m *LanguageShare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LanguageShare.Marshal(b, m, deterministic)
+}
+func (m *LanguageShare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LanguageShare.Merge(m, src)
+}
+func (m *LanguageShare) XXX_Size() int {
+	return xxx_messageInfo_LanguageShare.Size(m)
+}
+func (m *LanguageShare) XXX_DiscardUnknown() {
+	xxx_messageInfo_LanguageShare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LanguageShare proto.InternalMessageInfo
+
+func (m *LanguageShare) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LanguageShare) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+func (m *LanguageShare) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *LanguageShare) GetPercent() float64 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+type SearchRequest struct {
+	Location             string   `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Language             string   `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Followers            string   `protobuf:"bytes,3,opt,name=followers,proto3" json:"followers,omitempty"`
+	Size                 int32    `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{3}
+}
+
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+}
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(m, src)
+}
+func (m *SearchRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchRequest.Size(m)
+}
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+
+func (m *SearchRequest) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetFollowers() string {
+	if m != nil {
+		return m.Followers
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetSize() int32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type Developer struct {
+	Login                string   `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	AvatarUrl            string   `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Bio                  string   `protobuf:"bytes,4,opt,name=bio,proto3" json:"bio,omitempty"`
+	Company              string   `protobuf:"bytes,5,opt,name=company,proto3" json:"company,omitempty"`
+	Location             string   `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	Followers            int32    `protobuf:"varint,7,opt,name=followers,proto3" json:"followers,omitempty"`
+	Following            int32    `protobuf:"varint,8,opt,name=following,proto3" json:"following,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Developer) Reset()         { *m = Developer{} }
+func (m *Developer) String() string { return proto.CompactTextString(m) }
+func (*Developer) ProtoMessage()    {}
+func (*Developer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{4}
+}
+
+func (m *Developer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Developer.Unmarshal(m, b)
+}
+func (m *Developer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Developer.Marshal(b, m, deterministic)
+}
+func (m *Developer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Developer.Merge(m, src)
+}
+func (m *Developer) XXX_Size() int {
+	return xxx_messageInfo_Developer.Size(m)
+}
+func (m *Developer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Developer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Developer proto.InternalMessageInfo
+
+func (m *Developer) GetLogin() string {
+	if m != nil {
+		return m.Login
+	}
+	return ""
+}
+
+func (m *Developer) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Developer) GetAvatarUrl() string {
+	if m != nil {
+		return m.AvatarUrl
+	}
+	return ""
+}
+
+func (m *Developer) GetBio() string {
+	if m != nil {
+		return m.Bio
+	}
+	return ""
+}
+
+func (m *Developer) GetCompany() string {
+	if m != nil {
+		return m.Company
+	}
+	return ""
+}
+
+func (m *Developer) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *Developer) GetFollowers() int32 {
+	if m != nil {
+		return m.Followers
+	}
+	return 0
+}
+
+func (m *Developer) GetFollowing() int32 {
+	if m != nil {
+		return m.Following
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	Developers           []*Developer `protobuf:"bytes,1,rep,name=developers,proto3" json:"developers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SearchResponse) Reset()         { *m = SearchResponse{} }
+func (m *SearchResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse) ProtoMessage()    {}
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{5}
+}
+
+func (m *SearchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse.Unmarshal(m, b)
+}
+func (m *SearchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse.Merge(m, src)
+}
+func (m *SearchResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse.Size(m)
+}
+func (m *SearchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse proto.InternalMessageInfo
+
+func (m *SearchResponse) GetDevelopers() []*Developer {
+	if m != nil {
+		return m.Developers
+	}
+	return nil
+}
+
+type SummaryRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SummaryRequest) Reset()         { *m = SummaryRequest{} }
+func (m *SummaryRequest) String() string { return proto.CompactTextString(m) }
+func (*SummaryRequest) ProtoMessage()    {}
+func (*SummaryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{6}
+}
+
+func (m *SummaryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SummaryRequest.Unmarshal(m, b)
+}
+func (m *SummaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SummaryRequest.Marshal(b, m, deterministic)
+}
+func (m *SummaryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SummaryRequest.Merge(m, src)
+}
+func (m *SummaryRequest) XXX_Size() int {
+	return xxx_messageInfo_SummaryRequest.Size(m)
+}
+func (m *SummaryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SummaryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SummaryRequest proto.InternalMessageInfo
+
+type SegmentSummary struct {
+	Name                 string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Location             string       `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Language             string       `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	Followers            string       `protobuf:"bytes,4,opt,name=followers,proto3" json:"followers,omitempty"`
+	Developers           []*Developer `protobuf:"bytes,5,rep,name=developers,proto3" json:"developers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SegmentSummary) Reset()         { *m = SegmentSummary{} }
+func (m *SegmentSummary) String() string { return proto.CompactTextString(m) }
+func (*SegmentSummary) ProtoMessage()    {}
+func (*SegmentSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{7}
+}
+
+func (m *SegmentSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentSummary.Unmarshal(m, b)
+}
+func (m *SegmentSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentSummary.Marshal(b, m, deterministic)
+}
+func (m *SegmentSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentSummary.Merge(m, src)
+}
+func (m *SegmentSummary) XXX_Size() int {
+	return xxx_messageInfo_SegmentSummary.Size(m)
+}
+func (m *SegmentSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentSummary proto.InternalMessageInfo
+
+func (m *SegmentSummary) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SegmentSummary) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *SegmentSummary) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *SegmentSummary) GetFollowers() string {
+	if m != nil {
+		return m.Followers
+	}
+	return ""
+}
+
+func (m *SegmentSummary) GetDevelopers() []*Developer {
+	if m != nil {
+		return m.Developers
+	}
+	return nil
+}
+
+type SummaryResponse struct {
+	Leaderboard          string            `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	Segments             []*SegmentSummary `protobuf:"bytes,2,rep,name=segments,proto3" json:"segments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SummaryResponse) Reset()         { *m = SummaryResponse{} }
+func (m *SummaryResponse) String() string { return proto.CompactTextString(m) }
+func (*SummaryResponse) ProtoMessage()    {}
+func (*SummaryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{8}
+}
+
+func (m *SummaryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SummaryResponse.Unmarshal(m, b)
+}
+func (m *SummaryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SummaryResponse.Marshal(b, m, deterministic)
+}
+func (m *SummaryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SummaryResponse.Merge(m, src)
+}
+func (m *SummaryResponse) XXX_Size() int {
+	return xxx_messageInfo_SummaryResponse.Size(m)
+}
+func (m *SummaryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SummaryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SummaryResponse proto.InternalMessageInfo
+
+func (m *SummaryResponse) GetLeaderboard() string {
+	if m != nil {
+		return m.Leaderboard
+	}
+	return ""
+}
+
+func (m *SummaryResponse) GetSegments() []*SegmentSummary {
+	if m != nil {
+		return m.Segments
+	}
+	return nil
+}
+
+type RepositoriesRequest struct {
+	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Sort                 string   `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	Order                string   `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	Language             string   `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	MinStars             int32    `protobuf:"varint,5,opt,name=min_stars,json=minStars,proto3" json:"min_stars,omitempty"`
+	Page                 int32    `protobuf:"varint,6,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage              int32    `protobuf:"varint,7,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	IncludeForks         bool     `protobuf:"varint,8,opt,name=include_forks,json=includeForks,proto3" json:"include_forks,omitempty"`
+	ExcludeArchived      bool     `protobuf:"varint,9,opt,name=exclude_archived,json=excludeArchived,proto3" json:"exclude_archived,omitempty"`
+	IncludePrivate       bool     `protobuf:"varint,10,opt,name=include_private,json=includePrivate,proto3" json:"include_private,omitempty"`
+	Affiliations         []string `protobuf:"bytes,11,rep,name=affiliations,proto3" json:"affiliations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RepositoriesRequest) Reset()         { *m = RepositoriesRequest{} }
+func (m *RepositoriesRequest) String() string { return proto.CompactTextString(m) }
+func (*RepositoriesRequest) ProtoMessage()    {}
+func (*RepositoriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{9}
+}
+
+func (m *RepositoriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RepositoriesRequest.Unmarshal(m, b)
+}
+func (m *RepositoriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RepositoriesRequest.Marshal(b, m, deterministic)
+}
+func (m *RepositoriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RepositoriesRequest.Merge(m, src)
+}
+func (m *RepositoriesRequest) XXX_Size() int {
+	return xxx_messageInfo_RepositoriesRequest.Size(m)
+}
+func (m *RepositoriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RepositoriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RepositoriesRequest proto.InternalMessageInfo
+
+func (m *RepositoriesRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *RepositoriesRequest) GetSort() string {
+	if m != nil {
+		return m.Sort
+	}
+	return ""
+}
+
+func (m *RepositoriesRequest) GetOrder() string {
+	if m != nil {
+		return m.Order
+	}
+	return ""
+}
+
+func (m *RepositoriesRequest) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *RepositoriesRequest) GetMinStars() int32 {
+	if m != nil {
+		return m.MinStars
+	}
+	return 0
+}
+
+func (m *RepositoriesRequest) GetPage() int32 {
+	if m != nil {
+		return m.Page
+	}
+	return 0
+}
+
+func (m *RepositoriesRequest) GetPerPage() int32 {
+	if m != nil {
+		return m.PerPage
+	}
+	return 0
+}
+
+func (m *RepositoriesRequest) GetIncludeForks() bool {
+	if m != nil {
+		return m.IncludeForks
+	}
+	return false
+}
+
+func (m *RepositoriesRequest) GetExcludeArchived() bool {
+	if m != nil {
+		return m.ExcludeArchived
+	}
+	return false
+}
+
+func (m *RepositoriesRequest) GetIncludePrivate() bool {
+	if m != nil {
+		return m.IncludePrivate
+	}
+	return false
+}
+
+func (m *RepositoriesRequest) GetAffiliations() []string {
+	if m != nil {
+		return m.Affiliations
+	}
+	return nil
+}
+
+type Repository struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Url                  string   `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Stars                int32    `protobuf:"varint,4,opt,name=stars,proto3" json:"stars,omitempty"`
+	Forks                int32    `protobuf:"varint,5,opt,name=forks,proto3" json:"forks,omitempty"`
+	Language             string   `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	Topics               []string `protobuf:"bytes,7,rep,name=topics,proto3" json:"topics,omitempty"`
+	License              string   `protobuf:"bytes,8,opt,name=license,proto3" json:"license,omitempty"`
+	CreatedAt            string   `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	PushedAt             string   `protobuf:"bytes,10,opt,name=pushed_at,json=pushedAt,proto3" json:"pushed_at,omitempty"`
+	Owner                string   `protobuf:"bytes,11,opt,name=owner,proto3" json:"owner,omitempty"`
+	Fork                 bool     `protobuf:"varint,12,opt,name=fork,proto3" json:"fork,omitempty"`
+	Private              bool     `protobuf:"varint,13,opt,name=private,proto3" json:"private,omitempty"`
+	Archived             bool     `protobuf:"varint,14,opt,name=archived,proto3" json:"archived,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Repository) Reset()         { *m = Repository{} }
+func (m *Repository) String() string { return proto.CompactTextString(m) }
+func (*Repository) ProtoMessage()    {}
+func (*Repository) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{10}
+}
+
+func (m *Repository) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Repository.Unmarshal(m, b)
+}
+func (m *Repository) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Repository.Marshal(b, m, deterministic)
+}
+func (m *Repository) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Repository.Merge(m, src)
+}
+func (m *Repository) XXX_Size() int {
+	return xxx_messageInfo_Repository.Size(m)
+}
+func (m *Repository) XXX_DiscardUnknown() {
+	xxx_messageInfo_Repository.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Repository proto.InternalMessageInfo
+
+func (m *Repository) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Repository) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Repository) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Repository) GetStars() int32 {
+	if m != nil {
+		return m.Stars
+	}
+	return 0
+}
+
+func (m *Repository) GetForks() int32 {
+	if m != nil {
+		return m.Forks
+	}
+	return 0
+}
+
+func (m *Repository) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *Repository) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *Repository) GetLicense() string {
+	if m != nil {
+		return m.License
+	}
+	return ""
+}
+
+func (m *Repository) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *Repository) GetPushedAt() string {
+	if m != nil {
+		return m.PushedAt
+	}
+	return ""
+}
+
+func (m *Repository) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Repository) GetFork() bool {
+	if m != nil {
+		return m.Fork
+	}
+	return false
+}
+
+func (m *Repository) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *Repository) GetArchived() bool {
+	if m != nil {
+		return m.Archived
+	}
+	return false
+}
+
+type RepositoriesResponse struct {
+	Repositories         []*Repository `protobuf:"bytes,1,rep,name=repositories,proto3" json:"repositories,omitempty"`
+	Total                int32         `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page                 int32         `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage              int32         `protobuf:"varint,4,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	Pages                int32         `protobuf:"varint,5,opt,name=pages,proto3" json:"pages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RepositoriesResponse) Reset()         { *m = RepositoriesResponse{} }
+func (m *RepositoriesResponse) String() string { return proto.CompactTextString(m) }
+func (*RepositoriesResponse) ProtoMessage()    {}
+func (*RepositoriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{11}
+}
+
+func (m *RepositoriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RepositoriesResponse.Unmarshal(m, b)
+}
+func (m *RepositoriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RepositoriesResponse.Marshal(b, m, deterministic)
+}
+func (m *RepositoriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RepositoriesResponse.Merge(m, src)
+}
+func (m *RepositoriesResponse) XXX_Size() int {
+	return xxx_messageInfo_RepositoriesResponse.Size(m)
+}
+func (m *RepositoriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RepositoriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RepositoriesResponse proto.InternalMessageInfo
+
+func (m *RepositoriesResponse) GetRepositories() []*Repository {
+	if m != nil {
+		return m.Repositories
+	}
+	return nil
+}
+
+func (m *RepositoriesResponse) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *RepositoriesResponse) GetPage() int32 {
+	if m != nil {
+		return m.Page
+	}
+	return 0
+}
+
+func (m *RepositoriesResponse) GetPerPage() int32 {
+	if m != nil {
+		return m.PerPage
+	}
+	return 0
+}
+
+func (m *RepositoriesResponse) GetPages() int32 {
+	if m != nil {
+		return m.Pages
+	}
+	return 0
+}
+
+type ContributionsRequest struct {
+	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	From                 string   `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   string   `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContributionsRequest) Reset()         { *m = ContributionsRequest{} }
+func (m *ContributionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ContributionsRequest) ProtoMessage()    {}
+func (*ContributionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{12}
+}
+
+func (m *ContributionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContributionsRequest.Unmarshal(m, b)
+}
+func (m *ContributionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContributionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ContributionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContributionsRequest.Merge(m, src)
+}
+func (m *ContributionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ContributionsRequest.Size(m)
+}
+func (m *ContributionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContributionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContributionsRequest proto.InternalMessageInfo
+
+func (m *ContributionsRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *ContributionsRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ContributionsRequest) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+type ContributionDay struct {
+	Date                 string   `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Count                int32    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContributionDay) Reset()         { *m = ContributionDay{} }
+func (m *ContributionDay) String() string { return proto.CompactTextString(m) }
+func (*ContributionDay) ProtoMessage()    {}
+func (*ContributionDay) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{13}
+}
+
+func (m *ContributionDay) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContributionDay.Unmarshal(m, b)
+}
+func (m *ContributionDay) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContributionDay.Marshal(b, m, deterministic)
+}
+func (m *ContributionDay) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContributionDay.Merge(m, src)
+}
+func (m *ContributionDay) XXX_Size() int {
+	return xxx_messageInfo_ContributionDay.Size(m)
+}
+func (m *ContributionDay) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContributionDay.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContributionDay proto.InternalMessageInfo
+
+func (m *ContributionDay) GetDate() string {
+	if m != nil {
+		return m.Date
+	}
+	return ""
+}
+
+func (m *ContributionDay) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type Streak struct {
+	Days                 int32    `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	Start                string   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End                  string   `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Streak) Reset()         { *m = Streak{} }
+func (m *Streak) String() string { return proto.CompactTextString(m) }
+func (*Streak) ProtoMessage()    {}
+func (*Streak) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{14}
+}
+
+func (m *Streak) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Streak.Unmarshal(m, b)
+}
+func (m *Streak) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Streak.Marshal(b, m, deterministic)
+}
+func (m *Streak) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Streak.Merge(m, src)
+}
+func (m *Streak) XXX_Size() int {
+	return xxx_messageInfo_Streak.Size(m)
+}
+func (m *Streak) XXX_DiscardUnknown() {
+	xxx_messageInfo_Streak.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Streak proto.InternalMessageInfo
+
+func (m *Streak) GetDays() int32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+func (m *Streak) GetStart() string {
+	if m != nil {
+		return m.Start
+	}
+	return ""
+}
+
+func (m *Streak) GetEnd() string {
+	if m != nil {
+		return m.End
+	}
+	return ""
+}
+
+type RepoContributions struct {
+	Repository           string   `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	Commits              int32    `protobuf:"varint,2,opt,name=commits,proto3" json:"commits,omitempty"`
+	PullRequests         int32    `protobuf:"varint,3,opt,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RepoContributions) Reset()         { *m = RepoContributions{} }
+func (m *RepoContributions) String() string { return proto.CompactTextString(m) }
+func (*RepoContributions) ProtoMessage()    {}
+func (*RepoContributions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{15}
+}
+
+func (m *RepoContributions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RepoContributions.Unmarshal(m, b)
+}
+func (m *RepoContributions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RepoContributions.Marshal(b, m, deterministic)
+}
+func (m *RepoContributions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RepoContributions.Merge(m, src)
+}
+func (m *RepoContributions) XXX_Size() int {
+	return xxx_messageInfo_RepoContributions.Size(m)
+}
+func (m *RepoContributions) XXX_DiscardUnknown() {
+	xxx_messageInfo_RepoContributions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RepoContributions proto.InternalMessageInfo
+
+func (m *RepoContributions) GetRepository() string {
+	if m != nil {
+		return m.Repository
+	}
+	return ""
+}
+
+func (m *RepoContributions) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *RepoContributions) GetPullRequests() int32 {
+	if m != nil {
+		return m.PullRequests
+	}
+	return 0
+}
+
+type ContributionsResponse struct {
+	Login                string               `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	From                 string               `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   string               `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	TotalContributions   int32                `protobuf:"varint,4,opt,name=total_contributions,json=totalContributions,proto3" json:"total_contributions,omitempty"`
+	Commits              int32                `protobuf:"varint,5,opt,name=commits,proto3" json:"commits,omitempty"`
+	PullRequests         int32                `protobuf:"varint,6,opt,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	Issues               int32                `protobuf:"varint,7,opt,name=issues,proto3" json:"issues,omitempty"`
+	Reviews              int32                `protobuf:"varint,8,opt,name=reviews,proto3" json:"reviews,omitempty"`
+	RepositoriesCreated  int32                `protobuf:"varint,9,opt,name=repositories_created,json=repositoriesCreated,proto3" json:"repositories_created,omitempty"`
+	Restricted           int32                `protobuf:"varint,10,opt,name=restricted,proto3" json:"restricted,omitempty"`
+	LongestStreak        *Streak              `protobuf:"bytes,11,opt,name=longest_streak,json=longestStreak,proto3" json:"longest_streak,omitempty"`
+	CurrentStreak        *Streak              `protobuf:"bytes,12,opt,name=current_streak,json=currentStreak,proto3" json:"current_streak,omitempty"`
+	ContributedTo        []*RepoContributions `protobuf:"bytes,13,rep,name=contributed_to,json=contributedTo,proto3" json:"contributed_to,omitempty"`
+	Calendar             []*ContributionDay   `protobuf:"bytes,14,rep,name=calendar,proto3" json:"calendar,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ContributionsResponse) Reset()         { *m = ContributionsResponse{} }
+func (m *ContributionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ContributionsResponse) ProtoMessage()    {}
+func (*ContributionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{16}
+}
+
+func (m *ContributionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContributionsResponse.Unmarshal(m, b)
+}
+func (m *ContributionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContributionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ContributionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContributionsResponse.Merge(m, src)
+}
+func (m *ContributionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ContributionsResponse.Size(m)
+}
+func (m *ContributionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContributionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContributionsResponse proto.InternalMessageInfo
+
+func (m *ContributionsResponse) GetLogin() string {
+	if m != nil {
+		return m.Login
+	}
+	return ""
+}
+
+func (m *ContributionsResponse) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ContributionsResponse) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *ContributionsResponse) GetTotalContributions() int32 {
+	if m != nil {
+		return m.TotalContributions
+	}
+	return 0
+}
+
+func (m *ContributionsResponse) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *ContributionsResponse) GetPullRequests() int32 {
+	if m != nil {
+		return m.PullRequests
+	}
+	return 0
+}
+
+func (m *ContributionsResponse) GetIssues() int32 {
+	if m != nil {
+		return m.Issues
+	}
+	return 0
+}
+
+func (m *ContributionsResponse) GetReviews() int32 {
+	if m != nil {
+		return m.Reviews
+	}
+	return 0
+}
+
+func (m *ContributionsResponse) GetRepositoriesCreated() int32 {
+	if m != nil {
+		return m.RepositoriesCreated
+	}
+	return 0
+}
+
+func (m *ContributionsResponse) GetRestricted() int32 {
+	if m != nil {
+		return m.Restricted
+	}
+	return 0
+}
+
+func (m *ContributionsResponse) GetLongestStreak() *Streak {
+	if m != nil {
+		return m.LongestStreak
+	}
+	return nil
+}
+
+func (m *ContributionsResponse) GetCurrentStreak() *Streak {
+	if m != nil {
+		return m.CurrentStreak
+	}
+	return nil
 }
 
-func (m *GithubResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GithubResponse.Unmarshal(m, b)
+func (m *ContributionsResponse) GetContributedTo() []*RepoContributions {
+	if m != nil {
+		return m.ContributedTo
+	}
+	return nil
 }
-func (m *GithubResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GithubResponse.Marshal(b, m, deterministic)
+
+func (m *ContributionsResponse) GetCalendar() []*ContributionDay {
+	if m != nil {
+		return m.Calendar
+	}
+	return nil
 }
-func (m *GithubResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GithubResponse.Merge(m, src)
+
+type TopStarsRequest struct {
+	ScoreBy              string               `protobuf:"bytes,1,opt,name=score_by,json=scoreBy,proto3" json:"score_by,omitempty"`
+	MinStars             *wrappers.Int32Value `protobuf:"bytes,2,opt,name=min_stars,json=minStars,proto3" json:"min_stars,omitempty"`
+	MinForks             *wrappers.Int32Value `protobuf:"bytes,3,opt,name=min_forks,json=minForks,proto3" json:"min_forks,omitempty"`
+	MinFollowers         *wrappers.Int32Value `protobuf:"bytes,4,opt,name=min_followers,json=minFollowers,proto3" json:"min_followers,omitempty"`
+	MinRepos             *wrappers.Int32Value `protobuf:"bytes,5,opt,name=min_repos,json=minRepos,proto3" json:"min_repos,omitempty"`
+	Weights              *ScoreWeights        `protobuf:"bytes,6,opt,name=weights,proto3" json:"weights,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
-func (m *GithubResponse) XXX_Size() int {
-	return xxx_messageInfo_GithubResponse.Size(m)
+
+func (m *TopStarsRequest) Reset()         { *m = TopStarsRequest{} }
+func (m *TopStarsRequest) String() string { return proto.CompactTextString(m) }
+func (*TopStarsRequest) ProtoMessage()    {}
+func (*TopStarsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{17}
 }
-func (m *GithubResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GithubResponse.DiscardUnknown(m)
+
+func (m *TopStarsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopStarsRequest.Unmarshal(m, b)
+}
+func (m *TopStarsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopStarsRequest.Marshal(b, m, deterministic)
+}
+func (m *TopStarsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopStarsRequest.Merge(m, src)
+}
+func (m *TopStarsRequest) XXX_Size() int {
+	return xxx_messageInfo_TopStarsRequest.Size(m)
+}
+func (m *TopStarsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopStarsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GithubResponse proto.InternalMessageInfo
+var xxx_messageInfo_TopStarsRequest proto.InternalMessageInfo
 
-func (m *GithubResponse) GetUsername() string {
+func (m *TopStarsRequest) GetScoreBy() string {
 	if m != nil {
-		return m.Username
+		return m.ScoreBy
 	}
 	return ""
 }
 
-func (m *GithubResponse) GetStarcount() int32 {
+func (m *TopStarsRequest) GetMinStars() *wrappers.Int32Value {
 	if m != nil {
-		return m.Starcount
+		return m.MinStars
+	}
+	return nil
+}
+
+func (m *TopStarsRequest) GetMinForks() *wrappers.Int32Value {
+	if m != nil {
+		return m.MinForks
+	}
+	return nil
+}
+
+func (m *TopStarsRequest) GetMinFollowers() *wrappers.Int32Value {
+	if m != nil {
+		return m.MinFollowers
+	}
+	return nil
+}
+
+func (m *TopStarsRequest) GetMinRepos() *wrappers.Int32Value {
+	if m != nil {
+		return m.MinRepos
+	}
+	return nil
+}
+
+func (m *TopStarsRequest) GetWeights() *ScoreWeights {
+	if m != nil {
+		return m.Weights
+	}
+	return nil
+}
+
+type ScoreWeights struct {
+	Stars                float64  `protobuf:"fixed64,1,opt,name=stars,proto3" json:"stars,omitempty"`
+	Forks                float64  `protobuf:"fixed64,2,opt,name=forks,proto3" json:"forks,omitempty"`
+	Followers            float64  `protobuf:"fixed64,3,opt,name=followers,proto3" json:"followers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScoreWeights) Reset()         { *m = ScoreWeights{} }
+func (m *ScoreWeights) String() string { return proto.CompactTextString(m) }
+func (*ScoreWeights) ProtoMessage()    {}
+func (*ScoreWeights) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{18}
+}
+
+func (m *ScoreWeights) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScoreWeights.Unmarshal(m, b)
+}
+func (m *ScoreWeights) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScoreWeights.Marshal(b, m, deterministic)
+}
+func (m *ScoreWeights) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScoreWeights.Merge(m, src)
+}
+func (m *ScoreWeights) XXX_Size() int {
+	return xxx_messageInfo_ScoreWeights.Size(m)
+}
+func (m *ScoreWeights) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScoreWeights.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScoreWeights proto.InternalMessageInfo
+
+func (m *ScoreWeights) GetStars() float64 {
+	if m != nil {
+		return m.Stars
 	}
 	return 0
 }
 
-func (m *GithubResponse) GetRepocount() int32 {
+func (m *ScoreWeights) GetForks() float64 {
 	if m != nil {
-		return m.Repocount
+		return m.Forks
 	}
 	return 0
 }
 
-func (m *GithubResponse) GetForkcount() int32 {
+func (m *ScoreWeights) GetFollowers() float64 {
 	if m != nil {
-		return m.Forkcount
+		return m.Followers
 	}
 	return 0
 }
 
-func (m *GithubResponse) GetLangmap() map[string]int32 {
+type TopStarsDev struct {
+	Developer            *Developer `protobuf:"bytes,1,opt,name=developer,proto3" json:"developer,omitempty"`
+	Stars                int32      `protobuf:"varint,2,opt,name=stars,proto3" json:"stars,omitempty"`
+	Forks                int32      `protobuf:"varint,3,opt,name=forks,proto3" json:"forks,omitempty"`
+	Repos                int32      `protobuf:"varint,4,opt,name=repos,proto3" json:"repos,omitempty"`
+	Score                float64    `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	Rank                 int32      `protobuf:"varint,6,opt,name=rank,proto3" json:"rank,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *TopStarsDev) Reset()         { *m = TopStarsDev{} }
+func (m *TopStarsDev) String() string { return proto.CompactTextString(m) }
+func (*TopStarsDev) ProtoMessage()    {}
+func (*TopStarsDev) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{19}
+}
+
+func (m *TopStarsDev) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopStarsDev.Unmarshal(m, b)
+}
+func (m *TopStarsDev) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopStarsDev.Marshal(b, m, deterministic)
+}
+func (m *TopStarsDev) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopStarsDev.Merge(m, src)
+}
+func (m *TopStarsDev) XXX_Size() int {
+	return xxx_messageInfo_TopStarsDev.Size(m)
+}
+func (m *TopStarsDev) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopStarsDev.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopStarsDev proto.InternalMessageInfo
+
+func (m *TopStarsDev) GetDeveloper() *Developer {
 	if m != nil {
-		return m.Langmap
+		return m.Developer
+	}
+	return nil
+}
+
+func (m *TopStarsDev) GetStars() int32 {
+	if m != nil {
+		return m.Stars
+	}
+	return 0
+}
+
+func (m *TopStarsDev) GetForks() int32 {
+	if m != nil {
+		return m.Forks
+	}
+	return 0
+}
+
+func (m *TopStarsDev) GetRepos() int32 {
+	if m != nil {
+		return m.Repos
+	}
+	return 0
+}
+
+func (m *TopStarsDev) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *TopStarsDev) GetRank() int32 {
+	if m != nil {
+		return m.Rank
+	}
+	return 0
+}
+
+type TopStarsResponse struct {
+	Leaderboard          string         `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	ScoreBy              string         `protobuf:"bytes,2,opt,name=score_by,json=scoreBy,proto3" json:"score_by,omitempty"`
+	Devs                 []*TopStarsDev `protobuf:"bytes,3,rep,name=devs,proto3" json:"devs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *TopStarsResponse) Reset()         { *m = TopStarsResponse{} }
+func (m *TopStarsResponse) String() string { return proto.CompactTextString(m) }
+func (*TopStarsResponse) ProtoMessage()    {}
+func (*TopStarsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da726d3ccdb16248, []int{20}
+}
+
+func (m *TopStarsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopStarsResponse.Unmarshal(m, b)
+}
+func (m *TopStarsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopStarsResponse.Marshal(b, m, deterministic)
+}
+func (m *TopStarsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopStarsResponse.Merge(m, src)
+}
+func (m *TopStarsResponse) XXX_Size() int {
+	return xxx_messageInfo_TopStarsResponse.Size(m)
+}
+func (m *TopStarsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopStarsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopStarsResponse proto.InternalMessageInfo
+
+func (m *TopStarsResponse) GetLeaderboard() string {
+	if m != nil {
+		return m.Leaderboard
+	}
+	return ""
+}
+
+func (m *TopStarsResponse) GetScoreBy() string {
+	if m != nil {
+		return m.ScoreBy
+	}
+	return ""
+}
+
+func (m *TopStarsResponse) GetDevs() []*TopStarsDev {
+	if m != nil {
+		return m.Devs
 	}
 	return nil
 }
@@ -138,28 +1592,134 @@ func init() {
 	proto.RegisterType((*GithubRequest)(nil), "protos.GithubRequest")
 	proto.RegisterType((*GithubResponse)(nil), "protos.GithubResponse")
 	proto.RegisterMapType((map[string]int32)(nil), "protos.GithubResponse.LangmapEntry")
+	proto.RegisterType((*LanguageShare)(nil), "protos.LanguageShare")
+	proto.RegisterType((*SearchRequest)(nil), "protos.SearchRequest")
+	proto.RegisterType((*Developer)(nil), "protos.Developer")
+	proto.RegisterType((*SearchResponse)(nil), "protos.SearchResponse")
+	proto.RegisterType((*SummaryRequest)(nil), "protos.SummaryRequest")
+	proto.RegisterType((*SegmentSummary)(nil), "protos.SegmentSummary")
+	proto.RegisterType((*SummaryResponse)(nil), "protos.SummaryResponse")
+	proto.RegisterType((*RepositoriesRequest)(nil), "protos.RepositoriesRequest")
+	proto.RegisterType((*Repository)(nil), "protos.Repository")
+	proto.RegisterType((*RepositoriesResponse)(nil), "protos.RepositoriesResponse")
+	proto.RegisterType((*ContributionsRequest)(nil), "protos.ContributionsRequest")
+	proto.RegisterType((*ContributionDay)(nil), "protos.ContributionDay")
+	proto.RegisterType((*Streak)(nil), "protos.Streak")
+	proto.RegisterType((*RepoContributions)(nil), "protos.RepoContributions")
+	proto.RegisterType((*ContributionsResponse)(nil), "protos.ContributionsResponse")
+	proto.RegisterType((*TopStarsRequest)(nil), "protos.TopStarsRequest")
+	proto.RegisterType((*ScoreWeights)(nil), "protos.ScoreWeights")
+	proto.RegisterType((*TopStarsDev)(nil), "protos.TopStarsDev")
+	proto.RegisterType((*TopStarsResponse)(nil), "protos.TopStarsResponse")
 }
 
 func init() { proto.RegisterFile("protos/github.proto", fileDescriptor_da726d3ccdb16248) }
 
 var fileDescriptor_da726d3ccdb16248 = []byte{
-	// 256 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x2e, 0x28, 0xca, 0x2f,
-	0xc9, 0x2f, 0xd6, 0x4f, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x03, 0xf3, 0x84, 0xd8, 0x20, 0x82,
-	0x4a, 0xda, 0x5c, 0xbc, 0xee, 0x60, 0xf1, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12, 0x21, 0x29,
-	0x2e, 0x8e, 0xd2, 0xe2, 0xd4, 0xa2, 0xbc, 0xc4, 0xdc, 0x54, 0x09, 0x46, 0x05, 0x46, 0x0d, 0xce,
-	0x20, 0x38, 0x5f, 0xa9, 0x91, 0x89, 0x8b, 0x0f, 0xa6, 0xba, 0xb8, 0x20, 0x3f, 0xaf, 0x38, 0x15,
-	0x9f, 0x72, 0x21, 0x19, 0x2e, 0xce, 0xe2, 0x92, 0xc4, 0xa2, 0xe4, 0xfc, 0xd2, 0xbc, 0x12, 0x09,
-	0x26, 0x05, 0x46, 0x0d, 0xd6, 0x20, 0x84, 0x00, 0x48, 0xb6, 0x28, 0xb5, 0x20, 0x1f, 0x22, 0xcb,
-	0x0c, 0x91, 0x85, 0x0b, 0x80, 0x64, 0xd3, 0xf2, 0x8b, 0xb2, 0x21, 0xb2, 0x2c, 0x10, 0x59, 0xb8,
-	0x80, 0x90, 0x2d, 0x17, 0x7b, 0x4e, 0x62, 0x5e, 0x7a, 0x6e, 0x62, 0x81, 0x04, 0xab, 0x02, 0xb3,
-	0x06, 0xb7, 0x91, 0x32, 0xc4, 0x5b, 0xc5, 0x7a, 0xa8, 0xce, 0xd3, 0xf3, 0x81, 0xa8, 0x72, 0xcd,
-	0x2b, 0x29, 0xaa, 0x0c, 0x82, 0xe9, 0x91, 0xb2, 0xe2, 0xe2, 0x41, 0x96, 0x10, 0x12, 0xe0, 0x62,
-	0xce, 0x4e, 0xad, 0x84, 0xba, 0x1f, 0xc4, 0x14, 0x12, 0xe1, 0x62, 0x2d, 0x4b, 0xcc, 0x29, 0x4d,
-	0x85, 0x3a, 0x1b, 0xc2, 0xb1, 0x62, 0xb2, 0x60, 0x34, 0x0a, 0x86, 0x05, 0x58, 0x70, 0x6a, 0x51,
-	0x59, 0x66, 0x72, 0xaa, 0x90, 0x13, 0x17, 0xbf, 0x5b, 0x6a, 0x49, 0x72, 0x86, 0x53, 0x65, 0x28,
-	0xcc, 0xe3, 0xa2, 0xe8, 0xae, 0x01, 0x07, 0xad, 0x94, 0x18, 0x76, 0x47, 0x2a, 0x31, 0x24, 0x41,
-	0x62, 0xc3, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff, 0x64, 0x60, 0x27, 0x72, 0xab, 0x01, 0x00, 0x00,
+	// 1647 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x58, 0xcd, 0x6e, 0xe3, 0xc8,
+	0x11, 0x8e, 0x7e, 0x2d, 0x96, 0x7e, 0xec, 0x6d, 0xff, 0x0c, 0xc7, 0xe3, 0x59, 0x38, 0xdc, 0xc3,
+	0x3a, 0x17, 0x1b, 0x6b, 0x23, 0xc1, 0x62, 0x83, 0x04, 0xeb, 0x9f, 0x6c, 0x10, 0x60, 0x90, 0x2c,
+	0xe8, 0x99, 0x49, 0x6e, 0x02, 0x45, 0xb5, 0x65, 0xc2, 0x14, 0x9b, 0xe9, 0x6e, 0xc9, 0xa3, 0x3c,
+	0x46, 0xde, 0x62, 0x12, 0xe4, 0x25, 0x72, 0xce, 0x03, 0xe4, 0x98, 0x73, 0x9e, 0x62, 0x51, 0xd5,
+	0xdd, 0x14, 0x29, 0xcb, 0x33, 0xba, 0x75, 0xfd, 0x76, 0x57, 0xd5, 0x57, 0x5d, 0x4d, 0xc2, 0x6e,
+	0x2e, 0x85, 0x16, 0xea, 0x6c, 0x92, 0xe8, 0xfb, 0xd9, 0xe8, 0x94, 0x28, 0xd6, 0x36, 0xcc, 0xc3,
+	0x2f, 0x27, 0x42, 0x4c, 0x52, 0x7e, 0x46, 0xe4, 0x68, 0x76, 0x77, 0xf6, 0x28, 0xa3, 0x3c, 0xe7,
+	0x52, 0x19, 0xbd, 0xe0, 0xef, 0x75, 0xe8, 0xff, 0x9e, 0x0c, 0x43, 0xfe, 0xd7, 0x19, 0x57, 0x9a,
+	0x1d, 0x42, 0x67, 0xa6, 0xb8, 0xcc, 0xa2, 0x29, 0xf7, 0x6b, 0xc7, 0xb5, 0x13, 0x2f, 0x2c, 0x68,
+	0xb6, 0x0b, 0x2d, 0x2d, 0xf2, 0x61, 0xe6, 0xd7, 0x8f, 0x6b, 0x27, 0xad, 0xb0, 0xa9, 0x45, 0xfe,
+	0x47, 0xb6, 0x0f, 0x6d, 0x64, 0x8e, 0x16, 0x7e, 0x83, 0xd4, 0x51, 0xe5, 0x6a, 0xc1, 0x8e, 0xc0,
+	0x4b, 0xa3, 0x6c, 0x32, 0x8b, 0x26, 0x5c, 0xf9, 0xcd, 0xe3, 0xda, 0x49, 0x27, 0x5c, 0x32, 0xd8,
+	0x57, 0xd0, 0x4f, 0xb2, 0x38, 0x9d, 0x8d, 0xf9, 0xf0, 0x4e, 0xc8, 0x07, 0xe5, 0xb7, 0x48, 0xa3,
+	0x67, 0x99, 0x3f, 0x20, 0x8f, 0xfd, 0x02, 0x76, 0xf8, 0x07, 0xa3, 0x14, 0xc9, 0xf8, 0x3e, 0x99,
+	0xf3, 0xb1, 0xdf, 0x26, 0xbd, 0x6d, 0xcb, 0xbf, 0xb4, 0x6c, 0xf6, 0x35, 0x6c, 0x3b, 0x7f, 0xb9,
+	0x4c, 0xe6, 0x91, 0xe6, 0xfe, 0x16, 0x69, 0x0e, 0x2c, 0xfb, 0x47, 0xc3, 0x65, 0x01, 0xf4, 0xa2,
+	0xbb, 0xbb, 0x24, 0x4d, 0x22, 0x9d, 0x88, 0x4c, 0xf9, 0x9d, 0xe3, 0xc6, 0x89, 0x17, 0x56, 0x78,
+	0xc1, 0xc7, 0x06, 0x0c, 0x5c, 0x52, 0x54, 0x2e, 0x32, 0xc5, 0x3f, 0x99, 0x95, 0x23, 0xf0, 0x94,
+	0x8e, 0x64, 0x2c, 0x66, 0x99, 0xb6, 0x99, 0x59, 0x32, 0x50, 0x2a, 0x79, 0x2e, 0x8c, 0xb4, 0x61,
+	0xa4, 0x05, 0x03, 0xa5, 0x18, 0xbf, 0x91, 0x36, 0x8d, 0xb4, 0x60, 0xb0, 0xdf, 0xc0, 0x16, 0xa6,
+	0x6c, 0x1a, 0xe5, 0x7e, 0xeb, 0xb8, 0x71, 0xd2, 0x3d, 0xff, 0xca, 0x94, 0x4d, 0x9d, 0x56, 0x8f,
+	0x77, 0xfa, 0xc6, 0x68, 0xfd, 0x2e, 0xd3, 0x72, 0x11, 0x3a, 0x1b, 0x76, 0x06, 0x1e, 0x56, 0x06,
+	0x77, 0x53, 0x7e, 0x9b, 0x1c, 0x30, 0xe7, 0x20, 0x44, 0x66, 0xa2, 0x85, 0x5c, 0x84, 0x1d, 0x2d,
+	0x72, 0x22, 0xd9, 0x45, 0xb9, 0x66, 0x5b, 0x64, 0xb0, 0xef, 0x0c, 0xde, 0x58, 0xc1, 0xed, 0x7d,
+	0x24, 0x79, 0xb9, 0x94, 0xaf, 0x01, 0xc4, 0x63, 0xc6, 0xe5, 0x50, 0x2f, 0x72, 0xee, 0x77, 0x28,
+	0x39, 0x1e, 0x71, 0xde, 0x2e, 0x72, 0xce, 0x7e, 0x0e, 0xbd, 0x29, 0x9f, 0x8e, 0xb8, 0x1c, 0x9a,
+	0x20, 0x3d, 0x0a, 0xb2, 0x6b, 0x78, 0xd7, 0xc8, 0x3a, 0xfc, 0x0e, 0x7a, 0xe5, 0x00, 0xd8, 0x0e,
+	0x34, 0x1e, 0xf8, 0xc2, 0xe6, 0x19, 0x97, 0x6c, 0x0f, 0x5a, 0xf3, 0x28, 0x9d, 0x71, 0x9b, 0x5e,
+	0x43, 0x7c, 0x57, 0xff, 0xb6, 0x16, 0x24, 0xd0, 0xaf, 0x9c, 0x8c, 0x31, 0x68, 0x96, 0xaa, 0x44,
+	0x6b, 0x34, 0x8f, 0x45, 0x2a, 0x24, 0x99, 0x7b, 0xa1, 0x21, 0x90, 0x3b, 0x5a, 0x68, 0xae, 0xa8,
+	0x2a, 0x8d, 0xd0, 0x10, 0xcc, 0x87, 0xad, 0x9c, 0xcb, 0x98, 0xdb, 0x7a, 0xd4, 0x42, 0x47, 0x06,
+	0x0b, 0xe8, 0xdf, 0x72, 0x04, 0x62, 0xa9, 0x55, 0x52, 0x11, 0x13, 0x68, 0x1c, 0x28, 0x1c, 0x4d,
+	0x32, 0x7b, 0x2e, 0xbb, 0x6b, 0x41, 0x9b, 0xa2, 0xa7, 0xa9, 0x78, 0xe4, 0x52, 0xd9, 0xa6, 0x59,
+	0x32, 0x30, 0x00, 0x95, 0xfc, 0x8d, 0x5b, 0x34, 0xd0, 0x3a, 0xf8, 0x6f, 0x0d, 0xbc, 0x1b, 0x3e,
+	0xe7, 0xa9, 0xc8, 0x39, 0x1d, 0x3c, 0x15, 0x93, 0xc4, 0x6d, 0x6a, 0x88, 0x22, 0xf0, 0x7a, 0x29,
+	0xf0, 0xd7, 0x00, 0xd1, 0x3c, 0xd2, 0x91, 0x1c, 0xce, 0x64, 0xea, 0xb6, 0x32, 0x9c, 0x77, 0x32,
+	0xc5, 0x44, 0x8f, 0x12, 0x41, 0x3b, 0x79, 0x21, 0x2e, 0x31, 0xfa, 0x58, 0x4c, 0xf3, 0x28, 0x5b,
+	0x50, 0x47, 0x7a, 0xa1, 0x23, 0x2b, 0xc1, 0xb6, 0x57, 0x82, 0xad, 0x04, 0xb4, 0xe5, 0x50, 0xec,
+	0x02, 0x2a, 0xa4, 0x49, 0x36, 0xf1, 0x3b, 0x65, 0x69, 0x92, 0x4d, 0x82, 0x6b, 0x18, 0xb8, 0xac,
+	0xda, 0x5e, 0xfb, 0x06, 0x60, 0xec, 0x62, 0x55, 0x7e, 0x8d, 0x60, 0xf8, 0x85, 0x83, 0x61, 0x91,
+	0x85, 0xb0, 0xa4, 0x14, 0xec, 0xc0, 0xe0, 0x76, 0x36, 0x9d, 0x46, 0x72, 0x61, 0x6b, 0x13, 0xfc,
+	0xab, 0x86, 0x7e, 0x27, 0x53, 0x9e, 0x69, 0x2b, 0x59, 0x8b, 0x8c, 0x72, 0x54, 0xf5, 0x4f, 0x94,
+	0xb0, 0xf1, 0xa9, 0x12, 0x36, 0x57, 0x4b, 0x58, 0x8d, 0xa0, 0xb5, 0x49, 0x04, 0x13, 0xd8, 0x2e,
+	0x22, 0xb0, 0x79, 0x38, 0x86, 0x6e, 0xca, 0xa3, 0x31, 0x97, 0x23, 0x11, 0xc9, 0xb1, 0x3d, 0x76,
+	0x99, 0xc5, 0xce, 0xa1, 0xa3, 0x4c, 0x8c, 0xca, 0xaf, 0xd3, 0x2e, 0x07, 0x6e, 0x97, 0x6a, 0xec,
+	0x61, 0xa1, 0x17, 0xfc, 0xaf, 0x0e, 0xbb, 0x45, 0xf3, 0x27, 0x5c, 0x6d, 0x72, 0xef, 0x23, 0x24,
+	0x85, 0xd4, 0x0e, 0x5a, 0xb8, 0x46, 0x10, 0x0a, 0x39, 0xe6, 0xd2, 0xdd, 0xfa, 0x44, 0x54, 0x72,
+	0xd6, 0x5c, 0xc9, 0xd9, 0x2b, 0xf0, 0xa6, 0x49, 0x36, 0xc4, 0xab, 0xd1, 0xdc, 0xf7, 0xad, 0xb0,
+	0x33, 0x4d, 0xb2, 0x5b, 0xa4, 0x71, 0x8b, 0x1c, 0x8d, 0xda, 0x06, 0xf5, 0xb8, 0x66, 0x2f, 0xa1,
+	0x93, 0x73, 0x39, 0x24, 0xbe, 0x41, 0x15, 0xf6, 0xe2, 0x8f, 0x28, 0x7a, 0x32, 0x3f, 0x3a, 0x1b,
+	0xce, 0x0f, 0x6f, 0xe3, 0xf9, 0x01, 0x1b, 0xcd, 0x8f, 0xee, 0x9a, 0xf9, 0xf1, 0xff, 0x3a, 0xc0,
+	0xf2, 0x7e, 0x5d, 0x8b, 0xbb, 0x63, 0xe8, 0x8e, 0xb9, 0x8a, 0x65, 0x92, 0x97, 0xa0, 0x57, 0x66,
+	0x61, 0x6f, 0x2e, 0x7b, 0x16, 0x97, 0x98, 0x71, 0x93, 0x3b, 0x73, 0x33, 0x18, 0x02, 0xb9, 0xcb,
+	0x09, 0xda, 0x0a, 0x0d, 0x51, 0xa9, 0x43, 0x7b, 0xa5, 0x0e, 0x07, 0x34, 0xb0, 0x93, 0xd8, 0x5c,
+	0xf1, 0x5e, 0x68, 0x29, 0xec, 0xfd, 0x34, 0x89, 0x79, 0xa6, 0xdc, 0x2d, 0xee, 0x48, 0xbc, 0x46,
+	0x62, 0xc9, 0x23, 0xcd, 0xc7, 0xc3, 0xc8, 0xdc, 0xe0, 0x5e, 0xe8, 0x59, 0xce, 0xa5, 0xc6, 0xc2,
+	0xe6, 0x33, 0x75, 0x6f, 0xa4, 0x60, 0x76, 0x33, 0x8c, 0x4b, 0x83, 0x13, 0x1c, 0x06, 0x7e, 0xd7,
+	0xe2, 0x04, 0x09, 0xcc, 0x09, 0x1e, 0xd4, 0xef, 0x51, 0x92, 0x69, 0x4d, 0x37, 0xaf, 0xcd, 0x7d,
+	0x9f, 0xd8, 0x8e, 0xc4, 0x68, 0x8a, 0x02, 0x0e, 0x48, 0x54, 0xd0, 0xc1, 0xc7, 0x1a, 0xec, 0x55,
+	0xf1, 0x6c, 0xdb, 0xe7, 0x57, 0xd0, 0x93, 0x25, 0xbe, 0x5f, 0x7b, 0x76, 0x00, 0x56, 0xf4, 0xf0,
+	0xc0, 0x5a, 0xe8, 0x28, 0x75, 0xb3, 0x86, 0x88, 0x02, 0x9f, 0x8d, 0x67, 0xf0, 0xd9, 0xac, 0xe2,
+	0x73, 0x0f, 0x5a, 0x39, 0x4d, 0x51, 0x5b, 0x15, 0x22, 0x82, 0xf7, 0xb0, 0x77, 0x2d, 0x32, 0x2d,
+	0x93, 0xd1, 0x8c, 0x90, 0xb2, 0x61, 0xef, 0xdd, 0x49, 0x31, 0x75, 0xbd, 0x87, 0x6b, 0x36, 0x80,
+	0xba, 0x16, 0x16, 0x1a, 0x75, 0x2d, 0x82, 0x5f, 0xc3, 0x76, 0xd9, 0xef, 0x4d, 0x44, 0xa0, 0x1b,
+	0x47, 0xda, 0xb9, 0xa3, 0xb5, 0x19, 0x83, 0xcb, 0x47, 0x8a, 0x21, 0x82, 0x1b, 0x68, 0xdf, 0x6a,
+	0xc9, 0xa3, 0x07, 0x63, 0xb3, 0x50, 0x64, 0xd3, 0x0a, 0x69, 0xed, 0x40, 0xe7, 0x7a, 0xdf, 0x10,
+	0x08, 0x4e, 0x9e, 0x8d, 0x1d, 0x38, 0x79, 0x36, 0x0e, 0x24, 0x7c, 0x81, 0x19, 0xad, 0x84, 0xc7,
+	0xbe, 0x04, 0x28, 0x52, 0xeb, 0xe6, 0x79, 0x89, 0x63, 0xa7, 0xcd, 0x34, 0xa1, 0xeb, 0x8b, 0xf2,
+	0x67, 0x49, 0xec, 0xef, 0x7c, 0x96, 0xa6, 0x43, 0x69, 0x32, 0xa4, 0x6c, 0xde, 0x7b, 0xc8, 0xb4,
+	0x59, 0x53, 0xc1, 0x3f, 0x9b, 0xb0, 0xbf, 0x92, 0x4f, 0x5b, 0xfb, 0x67, 0x27, 0xe4, 0xe7, 0x52,
+	0xc9, 0xce, 0x60, 0x97, 0x0a, 0x3e, 0x8c, 0xcb, 0x8e, 0x6d, 0x79, 0x19, 0x89, 0xaa, 0x31, 0x96,
+	0x62, 0x68, 0x7d, 0x26, 0x86, 0xf6, 0xd3, 0x18, 0xb0, 0x19, 0x13, 0xa5, 0x66, 0xdc, 0xcd, 0x4d,
+	0x4b, 0xa1, 0x5b, 0xc9, 0xe7, 0x09, 0x7f, 0x54, 0x76, 0x64, 0x3a, 0x92, 0x7d, 0x03, 0x7b, 0x65,
+	0xbc, 0x0e, 0x6d, 0x1f, 0xda, 0x87, 0xd5, 0x6e, 0x59, 0x76, 0x6d, 0x44, 0xa6, 0x0e, 0x4a, 0xcb,
+	0x24, 0x46, 0x45, 0x20, 0xc5, 0x12, 0x87, 0xfd, 0x12, 0x06, 0xa9, 0xc8, 0x26, 0x5c, 0xe9, 0xa1,
+	0x22, 0x28, 0x50, 0xb3, 0x76, 0xcf, 0x07, 0xc5, 0x34, 0x21, 0x6e, 0xd8, 0xb7, 0x5a, 0x86, 0x44,
+	0xb3, 0x78, 0x26, 0x25, 0xcf, 0x0a, 0xb3, 0xde, 0x7a, 0x33, 0xab, 0x65, 0xcd, 0xbe, 0x87, 0x41,
+	0x91, 0x5c, 0x3e, 0x1e, 0x6a, 0xe1, 0xf7, 0xa9, 0x35, 0x5f, 0x96, 0x5b, 0xb3, 0x5a, 0xd7, 0x7e,
+	0xc9, 0xe0, 0xad, 0x60, 0x17, 0xd0, 0x89, 0xa3, 0x94, 0x67, 0xe3, 0x48, 0xfa, 0x03, 0xb2, 0x7d,
+	0xe1, 0x6c, 0x57, 0xfa, 0x20, 0x2c, 0x14, 0x83, 0xff, 0xd4, 0x61, 0xfb, 0xad, 0xc8, 0x69, 0xdc,
+	0xb8, 0xc6, 0x7b, 0x09, 0x1d, 0x15, 0x0b, 0xc9, 0xf1, 0xeb, 0xc5, 0x40, 0x65, 0x8b, 0xe8, 0xab,
+	0x05, 0xfb, 0xb6, 0x3c, 0xad, 0xea, 0x14, 0xd7, 0xab, 0x53, 0xf3, 0x35, 0x75, 0xea, 0xbe, 0xa6,
+	0x4e, 0xff, 0x90, 0xe9, 0x8b, 0xf3, 0xf7, 0xf8, 0x18, 0x2d, 0x8d, 0x32, 0x6b, 0x69, 0x6e, 0xe5,
+	0xc6, 0x66, 0x96, 0x66, 0x60, 0x7d, 0x0f, 0x7d, 0x63, 0x59, 0x7e, 0x59, 0x7c, 0xc6, 0xba, 0x47,
+	0xd6, 0xd6, 0xc0, 0xed, 0x6d, 0x9e, 0xfc, 0xad, 0xcd, 0xf6, 0x36, 0x6f, 0xff, 0x53, 0xd8, 0x7a,
+	0xe4, 0xc9, 0xe4, 0xde, 0xe2, 0xb4, 0x7b, 0xbe, 0x57, 0x54, 0x11, 0x33, 0xf2, 0x67, 0x23, 0x0b,
+	0x9d, 0x52, 0xf0, 0x17, 0xe8, 0x95, 0x05, 0xcb, 0xe9, 0x54, 0xa3, 0x57, 0xf3, 0xea, 0x74, 0xaa,
+	0x1b, 0x2e, 0x11, 0x4f, 0x1f, 0xc0, 0xb5, 0xd2, 0xeb, 0x29, 0xf8, 0x47, 0x0d, 0xba, 0xae, 0x50,
+	0x37, 0x7c, 0x8e, 0x9f, 0x31, 0xc5, 0x43, 0x89, 0xbc, 0xaf, 0x7d, 0x4c, 0x2d, 0x75, 0x96, 0x47,
+	0xa9, 0xaf, 0x1d, 0x94, 0x8d, 0xf2, 0xa0, 0xdc, 0x83, 0x96, 0x49, 0x96, 0x1d, 0xaa, 0x44, 0x90,
+	0x07, 0x0c, 0xce, 0x6f, 0xd9, 0x60, 0x90, 0xc0, 0xfb, 0x43, 0x46, 0xd9, 0x83, 0x7b, 0xa3, 0xe0,
+	0x3a, 0xf8, 0x00, 0x3b, 0x4b, 0x50, 0x6d, 0xfc, 0x70, 0x2b, 0xe3, 0xae, 0x5e, 0xc5, 0xdd, 0xd7,
+	0xd0, 0x1c, 0xf3, 0x39, 0x9e, 0x12, 0x71, 0xbd, 0xeb, 0x02, 0x2d, 0x25, 0x24, 0x24, 0x85, 0xf3,
+	0x7f, 0x37, 0xdc, 0xa7, 0xfb, 0x2d, 0x97, 0xf3, 0x24, 0xe6, 0xec, 0x0a, 0xb6, 0x7f, 0xe0, 0x3a,
+	0xbe, 0xbf, 0x5a, 0xbc, 0x73, 0xd3, 0x63, 0x7f, 0xf5, 0x83, 0x91, 0x70, 0x7f, 0x78, 0xb0, 0xfe,
+	0x3b, 0x32, 0xf8, 0x19, 0xfb, 0x2d, 0x74, 0xcd, 0x73, 0x1c, 0x5d, 0xa8, 0xa5, 0x7d, 0xe5, 0xcb,
+	0xe7, 0xf0, 0x60, 0x95, 0x5d, 0xd8, 0x5f, 0x42, 0x8f, 0xce, 0xe0, 0x1e, 0xdd, 0x4b, 0xcd, 0xca,
+	0xfb, 0xfc, 0xf0, 0xc5, 0x13, 0x7e, 0xe1, 0xe2, 0x4f, 0xb0, 0xf3, 0x26, 0x51, 0xba, 0x3c, 0xd4,
+	0xd9, 0xab, 0x27, 0x63, 0x7b, 0xf9, 0x74, 0x3d, 0x3c, 0x5a, 0x2f, 0x2c, 0x1c, 0xde, 0x02, 0xa3,
+	0x33, 0x55, 0x2f, 0xee, 0xa3, 0x75, 0x57, 0x46, 0xe1, 0xf3, 0xf5, 0x33, 0xd2, 0xc2, 0xe9, 0x0d,
+	0xf4, 0xc9, 0xa9, 0x2b, 0x0c, 0x7b, 0xb1, 0x5a, 0x2a, 0xe7, 0xca, 0x7f, 0x2a, 0x70, 0x5e, 0x46,
+	0xe6, 0x3f, 0xcd, 0xc5, 0x4f, 0x03, 0x00, 0x7b, 0x1c, 0x22, 0x7c, 0xc5, 0x11, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -175,6 +1735,11 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type GithubServiceClient interface {
 	FetchByUsername(ctx context.Context, in *GithubRequest, opts ...grpc.CallOption) (*GithubResponse, error)
+	SearchUsers(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	FetchSummary(ctx context.Context, in *SummaryRequest, opts ...grpc.CallOption) (*SummaryResponse, error)
+	ListRepositories(ctx context.Context, in *RepositoriesRequest, opts ...grpc.CallOption) (*RepositoriesResponse, error)
+	FetchContributions(ctx context.Context, in *ContributionsRequest, opts ...grpc.CallOption) (*ContributionsResponse, error)
+	FetchTopStars(ctx context.Context, in *TopStarsRequest, opts ...grpc.CallOption) (*TopStarsResponse, error)
 }
 
 type githubServiceClient struct {
@@ -194,9 +1759,59 @@ func (c *githubServiceClient) FetchByUsername(ctx context.Context, in *GithubReq
 	return out, nil
 }
 
+func (c *githubServiceClient) SearchUsers(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/protos.GithubService/SearchUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubServiceClient) FetchSummary(ctx context.Context, in *SummaryRequest, opts ...grpc.CallOption) (*SummaryResponse, error) {
+	out := new(SummaryResponse)
+	err := c.cc.Invoke(ctx, "/protos.GithubService/FetchSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubServiceClient) ListRepositories(ctx context.Context, in *RepositoriesRequest, opts ...grpc.CallOption) (*RepositoriesResponse, error) {
+	out := new(RepositoriesResponse)
+	err := c.cc.Invoke(ctx, "/protos.GithubService/ListRepositories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubServiceClient) FetchContributions(ctx context.Context, in *ContributionsRequest, opts ...grpc.CallOption) (*ContributionsResponse, error) {
+	out := new(ContributionsResponse)
+	err := c.cc.Invoke(ctx, "/protos.GithubService/FetchContributions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *githubServiceClient) FetchTopStars(ctx context.Context, in *TopStarsRequest, opts ...grpc.CallOption) (*TopStarsResponse, error) {
+	out := new(TopStarsResponse)
+	err := c.cc.Invoke(ctx, "/protos.GithubService/FetchTopStars", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GithubServiceServer is the server API for GithubService service.
 type GithubServiceServer interface {
 	FetchByUsername(context.Context, *GithubRequest) (*GithubResponse, error)
+	SearchUsers(context.Context, *SearchRequest) (*SearchResponse, error)
+	FetchSummary(context.Context, *SummaryRequest) (*SummaryResponse, error)
+	ListRepositories(context.Context, *RepositoriesRequest) (*RepositoriesResponse, error)
+	FetchContributions(context.Context, *ContributionsRequest) (*ContributionsResponse, error)
+	FetchTopStars(context.Context, *TopStarsRequest) (*TopStarsResponse, error)
 }
 
 // UnimplementedGithubServiceServer can be embedded to have forward compatible implementations.
@@ -206,6 +1821,21 @@ type UnimplementedGithubServiceServer struct {
 func (*UnimplementedGithubServiceServer) FetchByUsername(ctx context.Context, req *GithubRequest) (*GithubResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FetchByUsername not implemented")
 }
+func (*UnimplementedGithubServiceServer) SearchUsers(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchUsers not implemented")
+}
+func (*UnimplementedGithubServiceServer) FetchSummary(ctx context.Context, req *SummaryRequest) (*SummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchSummary not implemented")
+}
+func (*UnimplementedGithubServiceServer) ListRepositories(ctx context.Context, req *RepositoriesRequest) (*RepositoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRepositories not implemented")
+}
+func (*UnimplementedGithubServiceServer) FetchContributions(ctx context.Context, req *ContributionsRequest) (*ContributionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchContributions not implemented")
+}
+func (*UnimplementedGithubServiceServer) FetchTopStars(ctx context.Context, req *TopStarsRequest) (*TopStarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchTopStars not implemented")
+}
 
 func RegisterGithubServiceServer(s *grpc.Server, srv GithubServiceServer) {
 	s.RegisterService(&_GithubService_serviceDesc, srv)
@@ -229,6 +1859,96 @@ func _GithubService_FetchByUsername_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GithubService_SearchUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubServiceServer).SearchUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.GithubService/SearchUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubServiceServer).SearchUsers(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubService_FetchSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubServiceServer).FetchSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.GithubService/FetchSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubServiceServer).FetchSummary(ctx, req.(*SummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubService_ListRepositories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepositoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubServiceServer).ListRepositories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.GithubService/ListRepositories",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubServiceServer).ListRepositories(ctx, req.(*RepositoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubService_FetchContributions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContributionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubServiceServer).FetchContributions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.GithubService/FetchContributions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubServiceServer).FetchContributions(ctx, req.(*ContributionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GithubService_FetchTopStars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopStarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GithubServiceServer).FetchTopStars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.GithubService/FetchTopStars",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GithubServiceServer).FetchTopStars(ctx, req.(*TopStarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _GithubService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.GithubService",
 	HandlerType: (*GithubServiceServer)(nil),
@@ -237,6 +1957,26 @@ var _GithubService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FetchByUsername",
 			Handler:    _GithubService_FetchByUsername_Handler,
 		},
+		{
+			MethodName: "SearchUsers",
+			Handler:    _GithubService_SearchUsers_Handler,
+		},
+		{
+			MethodName: "FetchSummary",
+			Handler:    _GithubService_FetchSummary_Handler,
+		},
+		{
+			MethodName: "ListRepositories",
+			Handler:    _GithubService_ListRepositories_Handler,
+		},
+		{
+			MethodName: "FetchContributions",
+			Handler:    _GithubService_FetchContributions_Handler,
+		},
+		{
+			MethodName: "FetchTopStars",
+			Handler:    _GithubService_FetchTopStars_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protos/github.proto",
@@ -0,0 +1,369 @@
+// Package snapshot records timestamped profile and top stars crawls so trends can be computed later
+package snapshot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"gogithub/github"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// ProfilePoint = counts of a single profile crawl
+type ProfilePoint struct {
+	At    time.Time `json:"at"`
+	Stars int       `json:"stars"`
+	Repos int       `json:"repos"`
+	Forks int       `json:"forks"`
+}
+
+// DevEntry = developer ranked by a top stars crawl, Rank starts at 1.
+// The counts let a crawl be ranked again by another score, see Rerank.
+type DevEntry struct {
+	Login     string `json:"login"`
+	Stars     int    `json:"stars"`
+	Forks     int    `json:"forks,omitempty"`
+	Followers int    `json:"followers,omitempty"`
+	Repos     int    `json:"repos,omitempty"`
+	Rank      int    `json:"rank"`
+}
+
+// TopStars = single top stars crawl of a leaderboard
+type TopStars struct {
+	At   time.Time  `json:"at"`
+	Devs []DevEntry `json:"devs"`
+}
+
+var (
+	profilesBucket = []byte("profiles")
+	topStarsBucket = []byte("topstars")
+)
+
+// dbName = file of the store inside its directory
+const dbName = "snapshots.db"
+
+// lockTimeout = how long an operation waits for another process holding the database
+const lockTimeout = 10 * time.Second
+
+// Store = snapshot database, a bbolt file with a bucket per profile and per leaderboard
+// holding one JSON record per crawl keyed by its time:
+//
+//	<dir>/snapshots.db: profiles/<login>, topstars/<leaderboard>
+//
+// The file is only opened for the duration of an operation, so the web server and the CLI can share it.
+// Prune drops the records older than Retention, the latest top stars crawl of every leaderboard
+// is kept in memory so a single process should record them.
+type Store struct {
+	// Retention = how long records are kept by Prune, zero keeps them forever
+	Retention time.Duration
+
+	mu     sync.Mutex
+	path   string
+	latest map[string]TopStars
+}
+
+// Open creates the store in dir, creating the directory and the database when needed
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	s := &Store{path: filepath.Join(dir, dbName), latest: make(map[string]TopStars)}
+	err := s.update(func(tx *bolt.Tx) error {
+		for _, kind := range [][]byte{profilesBucket, topStarsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(kind); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// bucketName returns the bucket of a profile or leaderboard, logins are case insensitive
+func bucketName(name string) []byte {
+	return []byte(strings.ToLower(name))
+}
+
+// timeKey encodes t so keys sort by time, the sign bit is flipped for times before 1970
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano())^1<<63)
+	return key
+}
+
+// RecordProfile records a profile crawl of login
+func (s *Store) RecordProfile(login string, point ProfilePoint) error {
+	return s.put(profilesBucket, login, point.At, point)
+}
+
+// Profiles returns the profile crawls of login recorded since the given time, oldest first
+func (s *Store) Profiles(login string, since time.Time) ([]ProfilePoint, error) {
+	var points []ProfilePoint
+	err := s.read(profilesBucket, login, since, func(v []byte) error {
+		var p ProfilePoint
+		if err := json.Unmarshal(v, &p); err != nil {
+			return err
+		}
+		points = append(points, p)
+		return nil
+	})
+	return points, err
+}
+
+// RecordTopStars records a top stars crawl of the leaderboard
+func (s *Store) RecordTopStars(leaderboard string, snap TopStars) error {
+	if err := s.put(topStarsBucket, leaderboard, snap.At, snap); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	name := string(bucketName(leaderboard))
+	if latest, ok := s.latest[name]; !ok || !snap.At.Before(latest.At) {
+		s.latest[name] = snap
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// TopStars returns the top stars crawls of the leaderboard recorded since the given time, oldest first
+func (s *Store) TopStars(leaderboard string, since time.Time) ([]TopStars, error) {
+	var snaps []TopStars
+	err := s.read(topStarsBucket, leaderboard, since, func(v []byte) error {
+		var snap TopStars
+		if err := json.Unmarshal(v, &snap); err != nil {
+			return err
+		}
+		snaps = append(snaps, snap)
+		return nil
+	})
+	return snaps, err
+}
+
+// LatestTopStars returns the last recorded top stars crawl of the leaderboard, ok is false when there is none.
+// The database is only read the first time, the crawl is shared so it must not be modified.
+func (s *Store) LatestTopStars(leaderboard string) (snap TopStars, ok bool, err error) {
+	name := string(bucketName(leaderboard))
+	s.mu.Lock()
+	snap, ok = s.latest[name]
+	s.mu.Unlock()
+	if ok {
+		return snap, true, nil
+	}
+
+	err = s.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(topStarsBucket).Bucket(bucketName(leaderboard))
+		if b == nil {
+			return nil
+		}
+		// The newest readable crawl
+		c := b.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			if err := json.Unmarshal(v, &snap); err != nil {
+				log.Printf("snapshot %s/%s: skipping corrupt record: %v", topStarsBucket, name, err)
+				continue
+			}
+			ok = true
+			return nil
+		}
+		return nil
+	})
+	if err != nil || !ok {
+		return TopStars{}, false, err
+	}
+	s.mu.Lock()
+	// A crawl recorded meanwhile is newer
+	if _, recorded := s.latest[name]; !recorded {
+		s.latest[name] = snap
+	}
+	s.mu.Unlock()
+	return snap, true, nil
+}
+
+// Prune drops the records older than Retention, profiles and leaderboards left empty are removed
+func (s *Store) Prune(now time.Time) error {
+	if s.Retention <= 0 {
+		return nil
+	}
+	cutoff := timeKey(now.Add(-s.Retention))
+	var removed []string
+	err := s.update(func(tx *bolt.Tx) error {
+		for _, kind := range [][]byte{profilesBucket, topStarsBucket} {
+			parent := tx.Bucket(kind)
+			var names, emptied [][]byte
+			if err := parent.ForEach(func(name, _ []byte) error {
+				names = append(names, name)
+				return nil
+			}); err != nil {
+				return err
+			}
+			for _, name := range names {
+				b := parent.Bucket(name)
+				// Deleting while iterating would skip keys, the old ones are collected first
+				var old [][]byte
+				c := b.Cursor()
+				for k, _ := c.First(); k != nil && bytes.Compare(k, cutoff) < 0; k, _ = c.Next() {
+					old = append(old, k)
+				}
+				for _, k := range old {
+					if err := b.Delete(k); err != nil {
+						return err
+					}
+				}
+				if k, _ := b.Cursor().First(); k == nil {
+					emptied = append(emptied, name)
+				}
+			}
+			for _, name := range emptied {
+				if err := parent.DeleteBucket(name); err != nil {
+					return err
+				}
+				if bytes.Equal(kind, topStarsBucket) {
+					removed = append(removed, string(name))
+				}
+			}
+		}
+		return nil
+	})
+	s.mu.Lock()
+	for _, name := range removed {
+		delete(s.latest, name)
+	}
+	s.mu.Unlock()
+	return err
+}
+
+// put stores the JSON record of name at the given time, a record at the same time is replaced
+func (s *Store) put(kind []byte, name string, at time.Time, record interface{}) error {
+	v, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket(kind).CreateBucketIfNotExists(bucketName(name))
+		if err != nil {
+			return err
+		}
+		return b.Put(timeKey(at), v)
+	})
+}
+
+// read decodes the records of name since the given time, oldest first. A name without records has none.
+// Records which can't be decoded are skipped.
+func (s *Store) read(kind []byte, name string, since time.Time, decode func(v []byte) error) error {
+	return s.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(kind).Bucket(bucketName(name))
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		k, v := c.First()
+		if !since.IsZero() {
+			k, v = c.Seek(timeKey(since))
+		}
+		for ; k != nil; k, v = c.Next() {
+			if err := decode(v); err != nil {
+				log.Printf("snapshot %s/%s: skipping corrupt record: %v", kind, bucketName(name), err)
+			}
+		}
+		return nil
+	})
+}
+
+// update runs fn in a read-write transaction
+func (s *Store) update(fn func(tx *bolt.Tx) error) error {
+	return s.with(false, func(db *bolt.DB) error { return db.Update(fn) })
+}
+
+// view runs fn in a read-only transaction, other readers don't wait for each other
+func (s *Store) view(fn func(tx *bolt.Tx) error) error {
+	return s.with(true, func(db *bolt.DB) error { return db.View(fn) })
+}
+
+// with opens the database for a single operation. bbolt locks the file while it's open,
+// a process holding it open would lock the others out.
+func (s *Store) with(readOnly bool, fn func(db *bolt.DB) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	db, err := bolt.Open(s.path, 0644, &bolt.Options{Timeout: lockTimeout, ReadOnly: readOnly})
+	if err != nil {
+		return fmt.Errorf("snapshot: open %s: %w", s.path, err)
+	}
+	if err := fn(db); err != nil {
+		db.Close()
+		return err
+	}
+	return db.Close()
+}
+
+// ParseWindow reads a trend window such as 30d, 12w or 72h
+func ParseWindow(value string) (time.Duration, error) {
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit > 0 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid window %q, use e.g. 30d, 12w or 72h", value)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid window %q, use e.g. 30d, 12w or 72h", value)
+	}
+	return d, nil
+}
+
+// NewProfilePoint returns the counts of a profile crawled at the given time
+func NewProfilePoint(at time.Time, data *github.RepoData) ProfilePoint {
+	return ProfilePoint{At: at, Stars: data.StarCount, Repos: data.RepoCount, Forks: data.ForkCount}
+}
+
+// NewTopStars returns the ranking of a top stars crawl, devs must be ranked by FetchAllStars
+func NewTopStars(at time.Time, devs []github.DevStar) TopStars {
+	snap := TopStars{At: at, Devs: make([]DevEntry, 0, len(devs))}
+	for _, dev := range devs {
+		c := dev.Counts()
+		snap.Devs = append(snap.Devs, DevEntry{
+			Login:     c.Login,
+			Stars:     c.Stars,
+			Forks:     c.Forks,
+			Followers: c.Followers,
+			Repos:     c.Repos,
+			Rank:      dev.Rank,
+		})
+	}
+	return snap
+}
+
+// Rerank returns the crawl ranked by the score of opts, like github.RankDevStars.
+// opts must have been validated.
+func Rerank(snap TopStars, opts github.ScoreOptions) TopStars {
+	counts := make([]github.DevCounts, len(snap.Devs))
+	for i, dev := range snap.Devs {
+		counts[i] = github.DevCounts{Login: dev.Login, Stars: dev.Stars, Forks: dev.Forks, Followers: dev.Followers, Repos: dev.Repos}
+	}
+	ranked := TopStars{At: snap.At, Devs: make([]DevEntry, 0, len(snap.Devs))}
+	for i, ds := range opts.Rank(counts) {
+		dev := snap.Devs[ds.Index]
+		dev.Rank = i + 1
+		ranked.Devs = append(ranked.Devs, dev)
+	}
+	return ranked
+}
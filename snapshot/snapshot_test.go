@@ -0,0 +1,262 @@
+package snapshot
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func openTestStore(t *testing.T) (*Store, string) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Open(dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return s, dir
+}
+
+func TestStoreProfiles(t *testing.T) {
+	s, dir := openTestStore(t)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		p := ProfilePoint{At: now.AddDate(0, 0, i), Stars: 10 * i}
+		if err := s.RecordProfile("Octocat", p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		login string
+		since time.Time
+		stars []int
+	}{
+		{"octocat", time.Time{}, []int{0, 10, 20}},
+		{"OCTOCAT", now.AddDate(0, 0, 1), []int{10, 20}},
+		{"octocat", now.AddDate(0, 0, 3), nil},
+		{"ghost", time.Time{}, nil},
+	}
+	for _, tt := range tests {
+		points, err := s.Profiles(tt.login, tt.since)
+		if err != nil {
+			t.Fatalf("Profiles(%s): %v", tt.login, err)
+		}
+		var stars []int
+		for _, p := range points {
+			stars = append(stars, p.Stars)
+		}
+		if len(stars) != len(tt.stars) {
+			t.Errorf("Profiles(%s, %s) = %v, want %v", tt.login, tt.since, stars, tt.stars)
+			continue
+		}
+		for i := range stars {
+			if stars[i] != tt.stars[i] {
+				t.Errorf("Profiles(%s, %s) = %v, want %v", tt.login, tt.since, stars, tt.stars)
+				break
+			}
+		}
+	}
+}
+
+func TestStoreCorruptRecord(t *testing.T) {
+	s, dir := openTestStore(t)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i, stars := range []int{1, 2, 3} {
+		if err := s.RecordProfile("octocat", ProfilePoint{At: now.AddDate(0, 0, i), Stars: stars}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A record written by an incompatible version
+	err := s.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(profilesBucket).Bucket([]byte("octocat")).Put(timeKey(now.AddDate(0, 0, 1)), []byte(`{"at":"2024-06-02T00:00:00Z","sta`))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	points, err := s.Profiles("octocat", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 || points[0].Stars != 1 || points[1].Stars != 3 {
+		t.Errorf("Profiles = %+v, want the records around the corrupt one", points)
+	}
+}
+
+func TestStoreSharedFile(t *testing.T) {
+	s, dir := openTestStore(t)
+	defer os.RemoveAll(dir)
+
+	// Another process, like the CLI next to the web server
+	other, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if err := other.RecordProfile("octocat", ProfilePoint{At: now, Stars: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RecordProfile("octocat", ProfilePoint{At: now.AddDate(0, 0, 1), Stars: 2}); err != nil {
+		t.Fatal(err)
+	}
+	points, err := other.Profiles("octocat", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 {
+		t.Errorf("Profiles = %+v, want the records of both stores", points)
+	}
+}
+
+func TestTimeKeyOrder(t *testing.T) {
+	times := []time.Time{
+		time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Unix(0, 0),
+		time.Unix(0, 1),
+		time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 1, 0, 0, 0, 1, time.UTC),
+	}
+	for i := 1; i < len(times); i++ {
+		if bytes.Compare(timeKey(times[i-1]), timeKey(times[i])) >= 0 {
+			t.Errorf("key of %s doesn't sort before %s", times[i-1], times[i])
+		}
+	}
+}
+
+func TestStoreLatestTopStars(t *testing.T) {
+	s, dir := openTestStore(t)
+	defer os.RemoveAll(dir)
+
+	if _, ok, err := s.LatestTopStars("Indonesia"); ok || err != nil {
+		t.Fatalf("LatestTopStars of an empty store = %v, %v, want none", ok, err)
+	}
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		snap := TopStars{At: now.AddDate(0, 0, i), Devs: []DevEntry{{Login: "dev", Stars: i, Rank: 1}}}
+		if err := s.RecordTopStars("Indonesia", snap); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// From memory, then from the database by a fresh store
+	reopened, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, store := range []*Store{s, reopened} {
+		latest, ok, err := store.LatestTopStars("indonesia")
+		if err != nil || !ok {
+			t.Fatalf("LatestTopStars = %v, %v", ok, err)
+		}
+		if !latest.At.Equal(now.AddDate(0, 0, 2)) || latest.Devs[0].Stars != 2 {
+			t.Errorf("LatestTopStars = %+v, want the third crawl", latest)
+		}
+	}
+
+	snaps, err := s.TopStars("Indonesia", now.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snaps) != 2 {
+		t.Errorf("TopStars since the second crawl = %d crawls, want 2", len(snaps))
+	}
+}
+
+func TestStorePrune(t *testing.T) {
+	s, dir := openTestStore(t)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		at := now.AddDate(0, 0, -10*i)
+		if err := s.RecordTopStars("board", TopStars{At: at}); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.RecordProfile("active", ProfilePoint{At: at, Stars: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.RecordProfile("gone", ProfilePoint{At: now.AddDate(0, 0, -100)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Retention is off by default
+	if err := s.Prune(now); err != nil {
+		t.Fatal(err)
+	}
+	if points, _ := s.Profiles("active", time.Time{}); len(points) != 5 {
+		t.Fatalf("%d points without retention, want 5", len(points))
+	}
+
+	s.Retention = 25 * 24 * time.Hour
+	if err := s.Prune(now); err != nil {
+		t.Fatal(err)
+	}
+	points, err := s.Profiles("active", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 3 {
+		t.Errorf("%d points after pruning, want the 3 of the last 25 days", len(points))
+	}
+	snaps, err := s.TopStars("board", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snaps) != 3 {
+		t.Errorf("%d crawls after pruning, want 3", len(snaps))
+	}
+	s.view(func(tx *bolt.Tx) error {
+		if tx.Bucket(profilesBucket).Bucket([]byte("gone")) != nil {
+			t.Error("profile without recent records still exists")
+		}
+		return nil
+	})
+
+	// Records added after pruning are kept
+	if err := s.RecordProfile("active", ProfilePoint{At: now.AddDate(0, 0, 1), Stars: 9}); err != nil {
+		t.Fatal(err)
+	}
+	points, _ = s.Profiles("active", time.Time{})
+	if len(points) != 4 || points[3].Stars != 9 {
+		t.Errorf("Profiles after appending = %+v", points)
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30d", 30 * 24 * time.Hour, false},
+		{"12w", 12 * 7 * 24 * time.Hour, false},
+		{"72h", 72 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"0d", 0, true},
+		{"-1w", 0, true},
+		{"d", 0, true},
+		{"1.5d", 0, true},
+		{"-3h", 0, true},
+		{"soon", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWindow(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseWindow(%q) = %s, %v, want %s, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package snapshot
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProfileTrend = change of a profile between the oldest and newest crawl of a window
+type ProfileTrend struct {
+	Login      string         `json:"login"`
+	From       time.Time      `json:"from"`
+	To         time.Time      `json:"to"`
+	StarsDelta int            `json:"stars_delta"`
+	ReposDelta int            `json:"repos_delta"`
+	ForksDelta int            `json:"forks_delta"`
+	Points     []ProfilePoint `json:"points"`
+}
+
+// NewProfileTrend computes the trend of points, oldest first
+func NewProfileTrend(login string, points []ProfilePoint) ProfileTrend {
+	trend := ProfileTrend{Login: login, Points: points}
+	if trend.Points == nil {
+		trend.Points = []ProfilePoint{}
+	}
+	if len(points) == 0 {
+		return trend
+	}
+	first, last := points[0], points[len(points)-1]
+	trend.From = first.At
+	trend.To = last.At
+	trend.StarsDelta = last.Stars - first.Stars
+	trend.ReposDelta = last.Repos - first.Repos
+	trend.ForksDelta = last.Forks - first.Forks
+	return trend
+}
+
+// DevMovement = developer of the latest crawl compared to an older one,
+// PreviousRank is 0 when the developer wasn't ranked then
+type DevMovement struct {
+	Login        string `json:"login"`
+	Stars        int    `json:"stars"`
+	StarsDelta   int    `json:"stars_delta"`
+	Rank         int    `json:"rank"`
+	PreviousRank int    `json:"previous_rank"`
+}
+
+// TopStarsTrend = rank changes of a leaderboard between two crawls
+type TopStarsTrend struct {
+	From time.Time     `json:"from"`
+	To   time.Time     `json:"to"`
+	Devs []DevMovement `json:"devs"`
+	// New = developers ranked now but not before, Dropped = the other way around
+	New     []DevEntry `json:"new"`
+	Dropped []DevEntry `json:"dropped"`
+}
+
+// CompareTopStars computes the rank changes from prev to cur, devs are listed in the order of cur.
+// Logins are matched case insensitively.
+func CompareTopStars(prev, cur TopStars) TopStarsTrend {
+	trend := TopStarsTrend{
+		From:    prev.At,
+		To:      cur.At,
+		Devs:    make([]DevMovement, 0, len(cur.Devs)),
+		New:     []DevEntry{},
+		Dropped: []DevEntry{},
+	}
+	before := make(map[string]DevEntry, len(prev.Devs))
+	for _, dev := range prev.Devs {
+		before[strings.ToLower(dev.Login)] = dev
+	}
+	now := make(map[string]bool, len(cur.Devs))
+	for _, dev := range cur.Devs {
+		now[strings.ToLower(dev.Login)] = true
+		move := DevMovement{Login: dev.Login, Stars: dev.Stars, Rank: dev.Rank}
+		if old, ok := before[strings.ToLower(dev.Login)]; ok {
+			move.PreviousRank = old.Rank
+			move.StarsDelta = dev.Stars - old.Stars
+		} else {
+			trend.New = append(trend.New, dev)
+		}
+		trend.Devs = append(trend.Devs, move)
+	}
+	for _, dev := range prev.Devs {
+		if !now[strings.ToLower(dev.Login)] {
+			trend.Dropped = append(trend.Dropped, dev)
+		}
+	}
+	sort.SliceStable(trend.Dropped, func(i, j int) bool { return trend.Dropped[i].Rank < trend.Dropped[j].Rank })
+	return trend
+}
+
+// DevPoint = stars and rank of a developer in a single top stars crawl
+type DevPoint struct {
+	At    time.Time `json:"at"`
+	Stars int       `json:"stars"`
+	Rank  int       `json:"rank"`
+}
+
+// DevSeries returns the crawls of snaps which ranked login, oldest first
+func DevSeries(snaps []TopStars, login string) []DevPoint {
+	series := []DevPoint{}
+	for _, snap := range snaps {
+		for _, dev := range snap.Devs {
+			if strings.EqualFold(dev.Login, login) {
+				series = append(series, DevPoint{At: snap.At, Stars: dev.Stars, Rank: dev.Rank})
+				break
+			}
+		}
+	}
+	return series
+}
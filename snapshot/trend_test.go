@@ -0,0 +1,96 @@
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCompareTopStars(t *testing.T) {
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	prev := TopStars{At: day, Devs: []DevEntry{
+		{Login: "alice", Stars: 300, Rank: 1},
+		{Login: "Bob", Stars: 200, Rank: 2},
+		{Login: "carol", Stars: 150, Rank: 3},
+		{Login: "dave", Stars: 100, Rank: 4},
+	}}
+	cur := TopStars{At: day.AddDate(0, 0, 7), Devs: []DevEntry{
+		{Login: "bob", Stars: 320, Rank: 1},
+		{Login: "alice", Stars: 310, Rank: 2},
+		{Login: "erin", Stars: 120, Rank: 3},
+	}}
+
+	trend := CompareTopStars(prev, cur)
+	if !trend.From.Equal(prev.At) || !trend.To.Equal(cur.At) {
+		t.Errorf("range = %s - %s, want %s - %s", trend.From, trend.To, prev.At, cur.At)
+	}
+	wantDevs := []DevMovement{
+		{Login: "bob", Stars: 320, StarsDelta: 120, Rank: 1, PreviousRank: 2},
+		{Login: "alice", Stars: 310, StarsDelta: 10, Rank: 2, PreviousRank: 1},
+		{Login: "erin", Stars: 120, StarsDelta: 0, Rank: 3, PreviousRank: 0},
+	}
+	if !reflect.DeepEqual(trend.Devs, wantDevs) {
+		t.Errorf("devs = %+v, want %+v", trend.Devs, wantDevs)
+	}
+	if !reflect.DeepEqual(trend.New, []DevEntry{cur.Devs[2]}) {
+		t.Errorf("new = %+v, want erin", trend.New)
+	}
+	if !reflect.DeepEqual(trend.Dropped, []DevEntry{prev.Devs[2], prev.Devs[3]}) {
+		t.Errorf("dropped = %+v, want carol and dave", trend.Dropped)
+	}
+}
+
+func TestCompareTopStarsEdges(t *testing.T) {
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	devs := []DevEntry{{Login: "alice", Stars: 10, Rank: 1}, {Login: "bob", Stars: 5, Rank: 2}}
+	tests := []struct {
+		name    string
+		prev    TopStars
+		cur     TopStars
+		moved   int
+		new     int
+		dropped int
+	}{
+		{"nothing recorded", TopStars{}, TopStars{}, 0, 0, 0},
+		{"first crawl", TopStars{}, TopStars{At: day, Devs: devs}, 2, 2, 0},
+		{"empty latest crawl", TopStars{At: day, Devs: devs}, TopStars{At: day}, 0, 0, 2},
+		{"same crawl", TopStars{At: day, Devs: devs}, TopStars{At: day, Devs: devs}, 2, 0, 0},
+	}
+	for _, tt := range tests {
+		trend := CompareTopStars(tt.prev, tt.cur)
+		if len(trend.Devs) != tt.moved || len(trend.New) != tt.new || len(trend.Dropped) != tt.dropped {
+			t.Errorf("%s: %d devs, %d new, %d dropped, want %d, %d, %d", tt.name,
+				len(trend.Devs), len(trend.New), len(trend.Dropped), tt.moved, tt.new, tt.dropped)
+		}
+		// Empty lists, not null, in the JSON
+		if trend.Devs == nil || trend.New == nil || trend.Dropped == nil {
+			t.Errorf("%s: nil list in %+v", tt.name, trend)
+		}
+	}
+
+	// Dropped developers are listed by their previous rank, not by the order of the crawl
+	prev := TopStars{At: day, Devs: []DevEntry{{Login: "c", Rank: 3}, {Login: "a", Rank: 1}, {Login: "b", Rank: 2}}}
+	trend := CompareTopStars(prev, TopStars{At: day})
+	for i, dev := range trend.Dropped {
+		if dev.Rank != i+1 {
+			t.Errorf("dropped = %+v, want ordered by rank", trend.Dropped)
+			break
+		}
+	}
+}
+
+func TestDevSeries(t *testing.T) {
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	snaps := []TopStars{
+		{At: day, Devs: []DevEntry{{Login: "Alice", Stars: 10, Rank: 2}}},
+		{At: day.AddDate(0, 0, 1), Devs: []DevEntry{{Login: "bob", Stars: 8, Rank: 1}}},
+		{At: day.AddDate(0, 0, 2), Devs: []DevEntry{{Login: "bob", Stars: 9, Rank: 2}, {Login: "alice", Stars: 12, Rank: 1}}},
+	}
+	want := []DevPoint{{At: day, Stars: 10, Rank: 2}, {At: day.AddDate(0, 0, 2), Stars: 12, Rank: 1}}
+	if got := DevSeries(snaps, "ALICE"); !reflect.DeepEqual(got, want) {
+		t.Errorf("DevSeries = %+v, want %+v", got, want)
+	}
+	if got := DevSeries(snaps, "nobody"); got == nil || len(got) != 0 {
+		t.Errorf("DevSeries of an unranked login = %#v, want an empty list", got)
+	}
+}